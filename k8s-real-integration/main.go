@@ -1,24 +1,30 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
 	"syscall"
 	"time"
 
+	"k8s-real-integration-go/pkg/audit"
+	"k8s-real-integration-go/pkg/executor"
 	"k8s-real-integration-go/pkg/k8s"
+	"k8s-real-integration-go/pkg/notifier"
+	"k8s-real-integration-go/pkg/publisher"
 	"k8s-real-integration-go/pkg/reflexion"
 	"k8s-real-integration-go/pkg/server"
 	"k8s-real-integration-go/pkg/watcher"
 )
 
 func main() {
-	fmt.Println("🚀 Starting K8s Real-Time Pod Monitoring System")
-	fmt.Println("📡 Connecting to Kubernetes cluster and Python Reflexion Service")
-
 	// Get default reflexion URL from environment or use localhost
 	defaultReflexionURL := os.Getenv("REFLEXION_SERVICE_URL")
 	if defaultReflexionURL == "" {
@@ -28,14 +34,96 @@ func main() {
 	// Parse command line flags
 	var (
 		namespace      = flag.String("namespace", "default", "Namespace to monitor")
+		namespaceList  = flag.String("namespaces", "", "Comma-separated namespaces to monitor, one watch each (overrides --namespace)")
+		allNamespaces  = flag.Bool("all-namespaces", false, "Monitor all namespaces, or every accessible one under namespace-scoped RBAC")
 		reflexionURL   = flag.String("reflexion-url", defaultReflexionURL, "Reflexion service URL")
 		testMode       = flag.Bool("test-mode", false, "Run in test mode (mock pod)")
+		listMode       = flag.Bool("list", false, "List the failed pods the agent would act on, then exit (read-only)")
+		listOutput     = flag.String("output", watcher.ListFormatTable, "Output format for --list: table or json")
 		httpPort       = flag.Int("http-port", 8080, "HTTP server port for kubectl execution")
-		dryRun         = flag.Bool("dry-run", false, "Enable dry-run mode for kubectl commands")
+		dryRun         = flag.Bool("dry-run", false, "Dry-run mode: kubectl commands and API mutations never change the cluster")
 		commandTimeout = flag.Int("command-timeout", 60, "Timeout for kubectl commands in seconds")
+		offline        = flag.Bool("offline", false, "Heuristic-only mode: never call the reflexion service")
+		oomNodeLimit   = flag.Int("oom-node-threshold", 3, "OOMKilled pods on one node before raising a node alert (0 disables)")
+		oomNodeWindow  = flag.Duration("oom-node-window", 10*time.Minute, "Time window for correlating OOMKilled pods by node")
+		cordonOnOOM    = flag.Bool("cordon-on-oom", false, "Cordon a node when the OOMKilled threshold is reached")
+		oscLimit       = flag.Int("oscillation-limit", 3, "Recurrences of the same error per workload after agent fixes before escalating (0 disables)")
+		oscWindow      = flag.Duration("oscillation-window", 30*time.Minute, "Time window for oscillation detection")
+		reflexionGzip  = flag.Bool("reflexion-gzip", false, "Gzip-compress request bodies sent to the reflexion service")
+		retryAttempts  = flag.Int("reflexion-retries", 3, "Attempts per reflexion request; refused connections and 502/503/504 responses are retried with backoff")
+		retryDelay     = flag.Duration("reflexion-retry-delay", time.Second, "Delay before the first reflexion retry, doubled for each further retry")
+		reflexionWait  = flag.Duration("reflexion-timeout", 120*time.Second, "Timeout of a single reflexion request attempt")
+		breakerLimit   = flag.Int("reflexion-breaker-threshold", 5, "Consecutive failed reflexion requests that open the circuit breaker (0 disables it)")
+		breakerCool    = flag.Duration("reflexion-breaker-cooldown", 5*time.Minute, "How long the open circuit breaker skips the reflexion service")
+		fixCanaries    = flag.Bool("fix-canaries", false, "Also fix pods that are part of a canary/blue-green rollout")
+		contexts       = flag.String("contexts", "", "Comma-separated kubeconfig contexts to watch (default: current cluster only); contexts other than the current one are report-only")
+		scanInterval   = flag.Duration("scan-interval", 10*time.Second, "Delay before re-establishing the pod watch after an error")
+		fullScanEvery  = flag.Duration("full-scan-interval", 5*time.Minute, "Interval between periodic full reconciliation scans")
+		recentFixes    = flag.Int("recent-fixes", 100, "Number of recent fixes kept for /api/v1/recent-fixes")
+		barePodPolicy  = flag.String("bare-pod-policy", watcher.BarePodPolicyRecreate, "Handling of failed pods without an owner: recreate, delete or skip")
+		publishURL     = flag.String("publish-url", "", "Publish fix results to this message bus URL (e.g. nats://localhost:4222)")
+		publishSubject = flag.String("publish-subject", "k8s.fix-results", "Topic/subject for published fix results")
+		summaryFormat  = flag.String("summary-format", watcher.SummaryFormatTable, "Session summary format: table, json or markdown")
+		stallTimeout   = flag.Duration("watch-stall-timeout", 5*time.Minute, "Restart the watch loop after this long without activity (0 disables)")
+		onSuccess      = flag.String("on-success", "", "Shell command run after a successful fix (fix details in FIX_* env vars)")
+		onFailure      = flag.String("on-failure", "", "Shell command run after a failed fix (fix details in FIX_* env vars)")
+		hookTimeout    = flag.Duration("hook-timeout", 30*time.Second, "Timeout for --on-success/--on-failure hook commands")
+		connectTimeout = flag.Duration("connect-timeout", k8s.DefaultConnectTimeout, "Timeout for the Kubernetes connection test at startup")
+		stuckAfter     = flag.Duration("stuck-terminating-after", 10*time.Minute, "Report pods terminating longer than this because of finalizers (0 disables)")
+		forceStuck     = flag.Bool("force-delete-stuck", false, "Remove known-safe finalizers from pods stuck terminating")
+		changeWindow   = flag.Duration("change-window", 15*time.Minute, "Correlate failures with rollouts and ConfigMap/Secret changes made within this window (0 disables)")
+		refix          = flag.Bool("refix", false, "Automatically fix again pods that break after an agent fix (default: re-analyze and report only)")
+		forceEvicted   = flag.Bool("force-delete-evicted", false, "Also delete evicted pods that have no controller to replace them")
+		safeFinalizers = flag.String("safe-finalizers", "", "Comma-separated extra finalizers that --force-delete-stuck may remove")
+		webhookURL     = flag.String("webhook-url", "", "POST fix outcome notifications as JSON to this URL (e.g. a Slack incoming webhook)")
+		notifyOn       = flag.String("notify-on", "success,failure,manual", "Fix outcomes that trigger a webhook notification: success, failure, manual")
+		auditLog       = flag.String("audit-log", "", "Append a JSON line per fix attempt and executed command batch to this file")
+		maxRuntime     = flag.Duration("max-runtime", 0, "Stop monitoring and print the session summary after this long (0 runs until interrupted)")
+		metricsPort    = flag.Int("metrics-port", 0, "Serve Prometheus metrics on this port at /metrics (0 disables)")
+		executeCmds    = flag.Bool("execute-commands", false, "Generate kubectl commands for AI strategies and run them through the HTTP server (default: the reflexion service applies YAML manifests)")
+		feedbackBatch  = flag.Int("feedback-batch-size", 0, "Send --execute-commands feedback to the reflexion service in batches of up to this many items (0 sends each immediately)")
+		feedbackWindow = flag.Duration("feedback-batch-window", 5*time.Second, "Longest time feedback waits in a batch before it is sent")
+		logLines       = flag.Int64("log-lines", 0, fmt.Sprintf("Container log lines fetched for analysis (0 uses per-error-type defaults, max %d)", k8s.MaxTailLines))
+		logSince       = flag.Duration("log-since", 0, "Only fetch container log lines written within this long before the fetch (0 means no limit)")
 	)
 	flag.Parse()
 
+	if *namespaceList != "" && *allNamespaces {
+		log.Fatalf("❌ --namespaces cannot be combined with --all-namespaces")
+	}
+	namespaces := []string{*namespace}
+	if *namespaceList != "" {
+		namespaces = splitList(*namespaceList)
+		if len(namespaces) == 0 {
+			log.Fatalf("❌ --namespaces lists no namespace")
+		}
+	}
+
+	if err := watcher.ValidateBarePodPolicy(*barePodPolicy); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if err := watcher.ValidateSummaryFormat(*summaryFormat); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if err := watcher.ValidateListFormat(*listOutput); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	// List mode prints only the list so its output can be piped (e.g. into jq)
+	if *listMode {
+		runListMode(splitList(*contexts), namespaces, *allNamespaces, *listOutput, func(pw *watcher.PodWatcher) {
+			pw.SetOffline(*offline)
+			pw.SetFixCanaries(*fixCanaries)
+			pw.SetBarePodPolicy(*barePodPolicy)
+			pw.SetForceDeleteEvicted(*forceEvicted)
+			pw.SetRefix(*refix)
+		})
+		return
+	}
+
+	fmt.Println("🚀 Starting K8s Real-Time Pod Monitoring System")
+	fmt.Println("📡 Connecting to Kubernetes cluster and Python Reflexion Service")
+
 	// Test mode - run the original mock test
 	if *testMode {
 		fmt.Println("🧪 Running in test mode with mock pod")
@@ -44,46 +132,271 @@ func main() {
 	}
 
 	// Real-time monitoring mode
-	fmt.Printf("🔍 Starting real-time monitoring for namespace: %s\n", *namespace)
+	if *allNamespaces {
+		fmt.Println("🔍 Starting real-time monitoring for all namespaces")
+	} else {
+		fmt.Printf("🔍 Starting real-time monitoring for namespace: %s\n", strings.Join(namespaces, ", "))
+	}
 	fmt.Printf("📡 Reflexion service URL: %s\n", *reflexionURL)
 	fmt.Printf("🌐 HTTP server port: %d\n", *httpPort)
 	fmt.Printf("🧪 Dry-run mode: %v\n", *dryRun)
+	fmt.Printf("🔌 Offline mode: %v\n", *offline)
+	if *dryRun && !*offline {
+		fmt.Println("⚠️  The reflexion service applies its own fixes; start it with KUBECTL_DRY_RUN=true for a full dry run")
+	}
 
-	// Create Kubernetes client
-	k8sClient, err := k8s.NewClient()
-	if err != nil {
-		log.Fatalf("❌ Failed to create Kubernetes client: %v", err)
+	// Create Kubernetes clients - one per context in multi-cluster mode
+	k8sClients := make(map[string]*k8s.Client)
+	var clusterNames []string
+	if *contexts == "" {
+		k8sClient, err := k8s.NewClient()
+		if err != nil {
+			log.Fatalf("❌ Failed to create Kubernetes client: %v", err)
+		}
+		k8sClient.SetConnectTimeout(*connectTimeout)
+		k8sClient.SetDryRun(*dryRun)
+		k8sClients[""] = k8sClient
+		clusterNames = append(clusterNames, "")
+	} else {
+		for _, contextName := range strings.Split(*contexts, ",") {
+			contextName = strings.TrimSpace(contextName)
+			if contextName == "" {
+				continue
+			}
+			k8sClient, err := k8s.NewClientForContext(contextName)
+			if err != nil {
+				log.Fatalf("❌ Failed to create Kubernetes client for context %s: %v", contextName, err)
+			}
+			k8sClient.SetConnectTimeout(*connectTimeout)
+			k8sClient.SetDryRun(*dryRun)
+			k8sClients[contextName] = k8sClient
+			clusterNames = append(clusterNames, contextName)
+		}
+		fmt.Printf("🌍 Multi-cluster mode: watching contexts %s\n", strings.Join(clusterNames, ", "))
 	}
+	reportOnly := reportOnlyContexts(clusterNames)
 
 	// Create reflexion client
-	reflexionClient := reflexion.NewClient(*reflexionURL)
+	reflexionClient := reflexion.NewClientWithOptions(*reflexionURL,
+		reflexion.WithMaxAttempts(*retryAttempts),
+		reflexion.WithBaseDelay(*retryDelay),
+		reflexion.WithTimeout(*reflexionWait),
+		reflexion.WithCircuitBreaker(*breakerLimit, *breakerCool),
+	)
+	reflexionClient.SetCompression(*reflexionGzip)
 
-	// Test reflexion service connection
-	if err := reflexionClient.HealthCheck(); err != nil {
-		log.Fatalf("❌ Reflexion service health check failed: %v", err)
+	// Test reflexion service connection (skipped in offline mode)
+	if *offline {
+		fmt.Println("🔌 Offline mode: skipping reflexion service, using built-in heuristics")
+	} else {
+		if err := reflexionClient.HealthCheck(); err != nil {
+			log.Fatalf("❌ Reflexion service health check failed: %v", err)
+		}
+		fmt.Println("✅ Reflexion service connection verified")
+	}
+
+	// Create fix result publisher
+	var fixPublisher publisher.Publisher
+	if *publishURL != "" {
+		p, err := publisher.NewPublisher(*publishURL, *publishSubject)
+		if err != nil {
+			log.Fatalf("❌ Failed to create fix result publisher: %v", err)
+		}
+		fixPublisher = p
+		fmt.Printf("📣 Publishing fix results to %s (subject: %s)\n", *publishURL, *publishSubject)
+	}
+
+	// Notify a webhook about fix outcomes
+	var fixNotifier notifier.Notifier
+	if *webhookURL != "" {
+		outcomes, err := notifier.ParseOutcomes(*notifyOn)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		fixNotifier = notifier.NewWebhookNotifier(*webhookURL, outcomes)
+		fmt.Printf("🔔 Sending fix notifications (%s) to webhook\n", *notifyOn)
+	}
+
+	// Record every fix attempt persistently
+	var auditLogger *audit.AuditLogger
+	if *auditLog != "" {
+		a, err := audit.NewAuditLogger(*auditLog)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		auditLogger = a
+		fmt.Printf("📒 Writing fix audit log to %s\n", *auditLog)
+	}
+
+	// Metrics are shared by all watchers and labelled by cluster
+	var metrics *watcher.Metrics
+	if *metricsPort > 0 {
+		metrics = watcher.NewMetrics()
+	}
+
+	// Create a pod watcher per cluster, and per namespace when cluster-wide access is forbidden
+	var podWatchers []*watcher.PodWatcher
+	var fixQueues []*watcher.FixQueue
+	for _, clusterName := range clusterNames {
+		watchNamespaces := namespaces
+		if *allNamespaces {
+			discovered, err := k8sClients[clusterName].DiscoverWatchableNamespaces(context.Background(), []string{*namespace})
+			if err != nil {
+				log.Fatalf("❌ Failed to discover watchable namespaces: %v", err)
+			}
+			watchNamespaces = discovered
+			if len(discovered) == 1 && discovered[0] == k8s.AllNamespaces {
+				fmt.Println("🌐 Watching all namespaces")
+			} else {
+				fmt.Printf("🔒 Watching accessible namespaces: %s\n", strings.Join(discovered, ", "))
+			}
+		}
+
+		// Nodes are cluster-wide, so the namespace watchers of a cluster correlate OOM kills
+		// together and feed one queue that fixes failures in cluster-wide priority order
+		oomNodes := watcher.NewOOMNodeTracker(*oomNodeLimit, *oomNodeWindow, *cordonOnOOM)
+		fixQueue := watcher.NewFixQueue()
+		fixQueues = append(fixQueues, fixQueue)
+
+		for _, watchNamespace := range watchNamespaces {
+			podWatcher := watcher.NewPodWatcher(k8sClients[clusterName], reflexionClient, watchNamespace)
+			if clusterName != "" {
+				podWatcher.SetCluster(clusterName)
+			}
+			podWatcher.SetOffline(*offline || reportOnly[clusterName])
+			podWatcher.SetOOMNodeTracker(oomNodes)
+			podWatcher.SetFixQueue(fixQueue)
+			podWatcher.SetOscillationLimit(*oscLimit, *oscWindow)
+			podWatcher.SetFixCanaries(*fixCanaries)
+			podWatcher.SetScanIntervals(*scanInterval, *fullScanEvery)
+			podWatcher.SetRecentFixesSize(*recentFixes)
+			podWatcher.SetBarePodPolicy(*barePodPolicy)
+			podWatcher.SetStallTimeout(*stallTimeout)
+			podWatcher.SetRefix(*refix)
+			podWatcher.SetChangeCorrelationWindow(*changeWindow)
+			podWatcher.SetForceDeleteEvicted(*forceEvicted)
+			podWatcher.SetLogOptions(*logLines, *logSince)
+			if *executeCmds {
+				podWatcher.SetCommandExecution(fmt.Sprintf("http://localhost:%d/api/v1/execute-commands", *httpPort))
+				podWatcher.SetFeedbackBatching(*feedbackBatch, *feedbackWindow)
+			}
+			podWatcher.SetStuckTerminating(*stuckAfter, *forceStuck, strings.Split(*safeFinalizers, ","))
+			if *onSuccess != "" || *onFailure != "" {
+				podWatcher.SetHooks(*onSuccess, *onFailure, *hookTimeout)
+			}
+			if fixPublisher != nil {
+				podWatcher.SetPublisher(fixPublisher)
+			}
+			if fixNotifier != nil {
+				podWatcher.SetNotifier(fixNotifier)
+			}
+			if metrics != nil {
+				podWatcher.SetMetrics(metrics)
+			}
+			if auditLogger != nil {
+				podWatcher.SetAuditLogger(auditLogger)
+			}
+			podWatchers = append(podWatchers, podWatcher)
+		}
 	}
-	fmt.Println("✅ Reflexion service connection verified")
 
 	// Create HTTP server for kubectl command execution
 	httpServer := server.NewHTTPServer(*httpPort, *dryRun, time.Duration(*commandTimeout)*time.Second)
 
+	if auditLogger != nil {
+		httpServer.SetAuditLogger(auditLogger)
+	}
+
+	// Serve recent fixes from all watchers over HTTP
+	httpServer.SetRecentFixesProvider(func() interface{} {
+		var fixes []watcher.FixRecord
+		for _, podWatcher := range podWatchers {
+			fixes = append(fixes, podWatcher.GetRecentFixes()...)
+		}
+		sort.Slice(fixes, func(i, j int) bool {
+			return fixes[i].Timestamp.After(fixes[j].Timestamp)
+		})
+		return fixes
+	})
+
+	// Report not-ready while any watch loop is wedged
+	httpServer.SetReadinessCheck(func() error {
+		for _, podWatcher := range podWatchers {
+			if err := podWatcher.Ready(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// Pause and resume fixes on all watchers at runtime
+	httpServer.SetPauseControl(func(pause bool) {
+		for _, podWatcher := range podWatchers {
+			if pause {
+				podWatcher.Pause()
+			} else {
+				podWatcher.Resume()
+			}
+		}
+	}, func() bool {
+		for _, podWatcher := range podWatchers {
+			if podWatcher.IsPaused() {
+				return true
+			}
+		}
+		return false
+	})
+
+	// Diagnose pods on demand without fixing them
+	httpServer.SetAnalyzer(func(ctx context.Context, cluster, namespace, name string) (interface{}, error) {
+		for _, podWatcher := range podWatchers {
+			if cluster == "" || podWatcher.GetCluster() == cluster {
+				return podWatcher.Analyze(ctx, namespace, name)
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", server.ErrUnknownCluster, cluster)
+	})
+
 	// Start HTTP server in a goroutine
 	go func() {
 		log.Printf("🌐 Starting HTTP server on port %d...", *httpPort)
 		if err := httpServer.Start(); err != nil {
-			log.Fatalf("❌ Failed to start HTTP server: %v", err)
+			switch {
+			case errors.Is(err, executor.ErrKubectlNotFound):
+				log.Fatalf("❌ Failed to start HTTP server: %v - install kubectl or add it to PATH", err)
+			case errors.Is(err, executor.ErrClusterUnreachable):
+				log.Fatalf("❌ Failed to start HTTP server: %v - check that kubectl's current context points at a reachable cluster", err)
+			default:
+				log.Fatalf("❌ Failed to start HTTP server: %v", err)
+			}
 		}
 	}()
 
+	// Serve Prometheus metrics on their own port
+	if metrics != nil {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", metrics.Handler())
+			log.Printf("📈 Serving metrics on port %d at /metrics", *metricsPort)
+			if err := http.ListenAndServe(fmt.Sprintf(":%d", *metricsPort), mux); err != nil {
+				log.Fatalf("❌ Failed to start metrics server: %v", err)
+			}
+		}()
+	}
+
 	// Give HTTP server time to start
 	time.Sleep(2 * time.Second)
 
-	// Create pod watcher
-	podWatcher := watcher.NewPodWatcher(k8sClient, reflexionClient, *namespace)
+	// Start the fix queues before the watchers that feed them
+	for _, fixQueue := range fixQueues {
+		fixQueue.Start()
+	}
 
-	// Start pod watcher
-	if err := podWatcher.Start(); err != nil {
-		log.Fatalf("❌ Failed to start pod watcher: %v", err)
+	// Start pod watchers
+	for _, podWatcher := range podWatchers {
+		if err := podWatcher.Start(); err != nil {
+			log.Fatalf("❌ Failed to start pod watcher: %v", err)
+		}
 	}
 
 	// Setup signal handling for graceful shutdown
@@ -99,29 +412,170 @@ func main() {
 	fmt.Println("🌐 HTTP Endpoints Available:")
 	fmt.Printf("   Health: http://localhost:%d/api/v1/health\n", *httpPort)
 	fmt.Printf("   Execute: http://localhost:%d/api/v1/execute-commands\n", *httpPort)
+	fmt.Printf("   Rollback: POST http://localhost:%d/api/v1/rollback\n", *httpPort)
 	fmt.Printf("   Status: http://localhost:%d/api/v1/kubectl-status\n", *httpPort)
+	fmt.Printf("   Recent fixes: http://localhost:%d/api/v1/recent-fixes\n", *httpPort)
+	fmt.Printf("   Readiness: http://localhost:%d/readyz\n", *httpPort)
+	fmt.Printf("   Analyze pod: POST http://localhost:%d/api/v1/analyze\n", *httpPort)
+	fmt.Printf("   Pause/resume fixes: POST http://localhost:%d/api/v1/pause, /api/v1/resume\n", *httpPort)
 
-	// Wait for signal
-	<-sigCh
-	fmt.Println("\n🛑 Received shutdown signal, stopping pod watcher...")
+	// Wait for a signal or the end of the allotted runtime
+	var runtimeExpired <-chan time.Time
+	if *maxRuntime > 0 {
+		fmt.Printf("⏱️  Monitoring will stop after %v\n", *maxRuntime)
+		runtimeExpired = time.After(*maxRuntime)
+	}
+	select {
+	case <-sigCh:
+		fmt.Println("\n🛑 Received shutdown signal, stopping pod watcher...")
+	case <-runtimeExpired:
+		fmt.Printf("\n⏱️  Maximum runtime of %v reached, stopping pod watcher...\n", *maxRuntime)
+	}
 
-	// Stop pod watcher
-	podWatcher.Stop()
+	// Stop pod watchers and their fix queues first, so no new command batches are sent
+	for _, podWatcher := range podWatchers {
+		podWatcher.Stop()
+	}
+	for _, fixQueue := range fixQueues {
+		fixQueue.Stop()
+	}
 
-	// Show processed pods
-	processedPods := podWatcher.GetProcessedPods()
-	if len(processedPods) > 0 {
-		fmt.Printf("📊 Processed %d failed pods:\n", len(processedPods))
-		for _, podKey := range processedPods {
-			fmt.Printf("   - %s\n", podKey)
+	// Then stop accepting HTTP requests and let running command batches finish
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), time.Duration(*commandTimeout)*time.Second)
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("⚠️  HTTP server did not shut down cleanly: %v", err)
+	}
+	cancelShutdown()
+
+	// Collect processed pods and fixes across clusters
+	var processedPods []string
+	var fixes []watcher.FixRecord
+	var counts watcher.SessionCounts
+	for _, podWatcher := range podWatchers {
+		fixes = append(fixes, podWatcher.GetRecentFixes()...)
+		counts = counts.Add(podWatcher.GetSessionCounts())
+		for _, podKey := range podWatcher.GetProcessedPods() {
+			if cluster := podWatcher.GetCluster(); cluster != "" {
+				podKey = cluster + ":" + podKey
+			}
+			processedPods = append(processedPods, podKey)
+		}
+	}
+
+	// Flush published fix results
+	if fixPublisher != nil {
+		fixPublisher.Close()
+	}
+	if auditLogger != nil {
+		if err := auditLogger.Close(); err != nil {
+			log.Printf("⚠️  Failed to close audit log: %v", err)
 		}
+	}
+
+	// Show the session summary
+	summary, err := watcher.NewSessionSummary(processedPods, counts, fixes).Render(*summaryFormat)
+	if err != nil {
+		log.Printf("❌ Failed to render session summary: %v", err)
 	} else {
-		fmt.Println("📊 No failed pods were detected during monitoring")
+		fmt.Print(summary)
 	}
 
 	fmt.Println("👋 Pod monitoring stopped successfully")
 }
 
+// splitList splits a comma-separated flag value, dropping blanks and duplicates
+func splitList(value string) []string {
+	var items []string
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		items = append(items, item)
+	}
+	return items
+}
+
+// runListMode prints the failed pods the agent would act on in each context and namespace.
+// It only reads from the cluster; configure applies the settings that decide each pod's action.
+// reportOnlyContexts returns the contexts whose pods may only be diagnosed. The reflexion
+// service and the kubectl execution server act on the kubeconfig's current context, so a
+// fix for a pod in any other context would be applied to the wrong cluster.
+func reportOnlyContexts(contextNames []string) map[string]bool {
+	reportOnly := make(map[string]bool)
+	if len(contextNames) == 0 || (len(contextNames) == 1 && contextNames[0] == "") {
+		return reportOnly
+	}
+
+	currentContext, err := k8s.CurrentContext()
+	if err != nil {
+		log.Printf("⚠️  Could not determine the current context, reporting only for all contexts: %v", err)
+	}
+	for _, contextName := range contextNames {
+		if contextName != currentContext {
+			reportOnly[contextName] = true
+			log.Printf("🔌 Context %s is not the current context (%s): fixes would be applied to the wrong cluster, reporting only",
+				contextName, currentContext)
+		}
+	}
+	return reportOnly
+}
+
+func runListMode(contextNames, namespaces []string, allNamespaces bool, format string, configure func(*watcher.PodWatcher)) {
+	if len(contextNames) == 0 {
+		contextNames = []string{""}
+	}
+
+	reportOnly := reportOnlyContexts(contextNames)
+
+	var failed []watcher.FailedPod
+	for _, contextName := range contextNames {
+		var k8sClient *k8s.Client
+		var err error
+		if contextName == "" {
+			k8sClient, err = k8s.NewClient()
+		} else {
+			k8sClient, err = k8s.NewClientForContext(contextName)
+		}
+		if err != nil {
+			log.Fatalf("❌ Failed to create Kubernetes client: %v", err)
+		}
+
+		listNamespaces := namespaces
+		if allNamespaces {
+			listNamespaces, err = k8sClient.DiscoverWatchableNamespaces(context.Background(), namespaces)
+			if err != nil {
+				log.Fatalf("❌ Failed to discover namespaces: %v", err)
+			}
+		}
+
+		for _, namespace := range listNamespaces {
+			podWatcher := watcher.NewPodWatcher(k8sClient, nil, namespace)
+			if contextName != "" {
+				podWatcher.SetCluster(contextName)
+			}
+			configure(podWatcher)
+			if reportOnly[contextName] {
+				podWatcher.SetOffline(true)
+			}
+
+			pods, err := podWatcher.ListFailedPods(context.Background())
+			if err != nil {
+				log.Fatalf("❌ %v", err)
+			}
+			failed = append(failed, pods...)
+		}
+	}
+
+	output, err := watcher.RenderFailedPods(failed, format)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	fmt.Print(output)
+}
+
 // runTestMode runs the original mock test
 func runTestMode(reflexionURL string) {
 	fmt.Println("🧪 Running mock pod test...")
@@ -3,11 +3,13 @@ K8s Reflexion Service - FastAPI Server
 Enhanced Kubernetes error resolution with LangGraph + Reflexion
 """
 import asyncio
+import gzip
 import os
 import json
+import zlib
 import sqlite3
 from datetime import datetime
-from typing import Dict, Any, Optional
+from typing import Dict, Any, List, Optional
 import uvicorn
 import structlog
 from fastapi import FastAPI, HTTPException, BackgroundTasks, Request
@@ -67,6 +69,56 @@ app.add_middleware(
     allow_headers=["*"],
 )
 
+class GzipRequestMiddleware:
+    """Decompresses request bodies sent with Content-Encoding: gzip (e.g. by the Go watcher's --reflexion-gzip)"""
+
+    def __init__(self, app):
+        self.app = app
+
+    async def __call__(self, scope, receive, send):
+        if scope["type"] != "http":
+            await self.app(scope, receive, send)
+            return
+
+        headers = dict(scope["headers"])
+        if headers.get(b"content-encoding", b"").lower() != b"gzip":
+            await self.app(scope, receive, send)
+            return
+
+        body = b""
+        more_body = True
+        while more_body:
+            message = await receive()
+            body += message.get("body", b"")
+            more_body = message.get("more_body", False)
+
+        try:
+            body = gzip.decompress(body)
+        except (OSError, EOFError, zlib.error):
+            response = JSONResponse({"detail": "Invalid gzip request body"}, status_code=400)
+            await response(scope, receive, send)
+            return
+
+        # Hand the app a plain body, as if the client had sent it uncompressed
+        scope = dict(scope)
+        scope["headers"] = [
+            (name, value) for name, value in scope["headers"]
+            if name not in (b"content-encoding", b"content-length")
+        ] + [(b"content-length", str(len(body)).encode())]
+
+        body_sent = False
+
+        async def receive_decompressed():
+            nonlocal body_sent
+            if not body_sent:
+                body_sent = True
+                return {"type": "http.request", "body": body, "more_body": False}
+            return await receive()
+
+        await self.app(scope, receive_decompressed, send)
+
+app.add_middleware(GzipRequestMiddleware)
+
 # Global workflow instance
 workflow_instance: Optional[ReflexiveK8sWorkflow] = None
 
@@ -89,6 +141,8 @@ class RealK8sData(BaseModel):
     events: list[Dict[str, Any]] = Field(..., description="Pod events")
     logs: list[str] = Field(..., description="Pod logs")
     container_statuses: Optional[list[Dict[str, Any]]] = Field(None, description="Container statuses")
+    container_diagnostics: Optional[list[Dict[str, Any]]] = Field(None, description="Interpreted exit codes, restarts and back-off state per container")
+    recent_changes: Optional[list[str]] = Field(None, description="Rollouts and ConfigMap/Secret changes shortly before the failure")
 
 class GoServiceErrorRequest(BaseModel):
     """Request from Go k8s-ai-agent-mvp service with real K8s data"""
@@ -182,8 +236,8 @@ async def startup_event():
         ai_command_generator = AICommandGenerator(openai_api_key)
         logger.info("Persistent memory systems initialized successfully")
         
-        # Initialize workflow with kubectl dry-run option (always disabled for real execution)
-        kubectl_dry_run = False
+        # Initialize workflow with kubectl dry-run option (KUBECTL_DRY_RUN=true disables real execution)
+        kubectl_dry_run = os.getenv("KUBECTL_DRY_RUN", "false").lower() == "true"
         
         workflow_instance = ReflexiveK8sWorkflow(
             openai_api_key=openai_api_key,
@@ -192,7 +246,10 @@ async def startup_event():
             kubectl_dry_run=kubectl_dry_run
         )
         
-        logger.info("⚡ KUBECTL REAL EXECUTION MODE ENABLED - All commands will be executed!")
+        if kubectl_dry_run:
+            logger.info("🧪 KUBECTL DRY-RUN MODE ENABLED - No commands will change the cluster")
+        else:
+            logger.info("⚡ KUBECTL REAL EXECUTION MODE ENABLED - All commands will be executed!")
         logger.info("Reflexion workflow initialized successfully")
         
     except Exception as e:
@@ -303,7 +360,9 @@ async def process_pod_error_with_real_data(request: GoServiceErrorRequest):
                 "pod": request.real_k8s_data.pod_spec,
                 "events": request.real_k8s_data.events,
                 "logs": request.real_k8s_data.logs,
-                "container_statuses": request.real_k8s_data.container_statuses
+                "container_statuses": request.real_k8s_data.container_statuses,
+                "container_diagnostics": request.real_k8s_data.container_diagnostics,
+                "recent_changes": request.real_k8s_data.recent_changes
             },
             # Standard fields
             "current_strategy": {},
@@ -1260,18 +1319,72 @@ async def not_found_handler(request: Request, exc):
         content={"error": "Endpoint not found", "path": request.url.path}
     )
 
-# DISABLED: kubectl Command Generation - Using YAML mode only
-# @app.post("/api/v1/executor/generate-commands", response_model=CommandExecutionResponse)
-async def generate_kubectl_commands_disabled(request: CommandExecutionRequest):
+# kubectl Command Generation - used by the Go agent's --execute-commands mode; the default
+# YAML manifest mode never calls it
+@app.post("/api/v1/executor/generate-commands", response_model=CommandExecutionResponse)
+async def generate_kubectl_commands(request: CommandExecutionRequest):
     """
-    DISABLED: kubectl Command Generation - Using YAML mode only
-    This endpoint is disabled to force YAML-only mode for better resource control
+    Generate kubectl commands for pod error fixing
+
+    This endpoint uses AI to generate kubectl commands based on the error type
+    and real K8s data, then returns the command URL for Go service execution.
     """
-    logger.warning("kubectl command generation disabled - system uses YAML mode only")
-    raise HTTPException(
-        status_code=503, 
-        detail="kubectl command generation disabled - system uses YAML manifest mode only"
-    )
+    if not ai_command_generator:
+        raise HTTPException(status_code=503, detail="AI Command Generator not initialized")
+
+    logger.info("Generating kubectl commands",
+                pod_name=request.pod_name,
+                error_type=request.error_type,
+                dry_run=request.dry_run)
+
+    start_time = datetime.now()
+
+    try:
+        # Convert real K8s data to the format expected by AI generator
+        ai_real_k8s_data = {
+            "pod": request.real_k8s_data.pod_spec,
+            "events": request.real_k8s_data.events,
+            "logs": request.real_k8s_data.logs
+        }
+
+        # Generate commands using AI
+        commands = await ai_command_generator.generate_kubectl_commands(
+            error_type=request.error_type,
+            pod_name=request.pod_name,
+            namespace=request.namespace,
+            strategy=request.strategy,
+            real_k8s_data=ai_real_k8s_data
+        )
+
+        execution_time = (datetime.now() - start_time).total_seconds()
+        total_commands = sum(len(cmd_list) for cmd_list in commands.values())
+
+        # Go service URL for command execution
+        go_service_url = os.getenv("GO_SERVICE_URL", "http://localhost:8080") + "/api/v1/execute-commands"
+
+        response = CommandExecutionResponse(
+            pod_name=request.pod_name,
+            namespace=request.namespace,
+            error_type=request.error_type,
+            commands_generated=total_commands,
+            commands_executed=0,  # Will be updated by Go service
+            success=True,
+            execution_time=execution_time,
+            commands=commands,
+            go_service_url=go_service_url,
+            message=f"Generated {total_commands} kubectl commands for {request.error_type}"
+        )
+
+        logger.info("kubectl commands generated successfully",
+                   pod_name=request.pod_name,
+                   commands_count=total_commands,
+                   execution_time=execution_time)
+
+        return response
+
+    except Exception as e:
+        logger.error("Failed to generate kubectl commands", error=str(e))
+        raise HTTPException(status_code=500, detail=f"Command generation failed: {str(e)}")
 
 # NEW: Phase 3.7 - Execution Feedback for Reflexion Learning
 @app.post("/api/v1/reflexion/execution-feedback", response_model=ExecutionFeedbackResponse)
@@ -1434,6 +1547,35 @@ async def process_execution_feedback(request: ExecutionFeedbackRequest):
         logger.error("Failed to process execution feedback", error=str(e))
         raise HTTPException(status_code=500, detail=f"Feedback processing failed: {str(e)}")
 
+@app.post("/api/v1/reflexion/execution-feedback/batch", response_model=List[ExecutionFeedbackResponse])
+async def process_execution_feedback_batch(requests: List[ExecutionFeedbackRequest]):
+    """
+    Process a batch of execution feedback items
+
+    The Go service batches feedback during mass-fix events; each item is processed
+    like a single execution-feedback call. A failed item does not fail the batch.
+    """
+    if not workflow_instance:
+        raise HTTPException(status_code=503, detail="Workflow not initialized")
+
+    logger.info(f"🔄 Processing execution feedback batch of {len(requests)} items")
+
+    responses = []
+    for request in requests:
+        try:
+            responses.append(await process_execution_feedback(request))
+        except HTTPException as e:
+            responses.append(ExecutionFeedbackResponse(
+                workflow_id=request.workflow_id,
+                feedback_processed=False,
+                reflexion_updated=False,
+                strategy_confidence_updated=False,
+                learning_summary={},
+                message=f"Feedback processing failed: {e.detail}"
+            ))
+
+    return responses
+
 # Test Management Endpoints
 class TestTriggerRequest(BaseModel):
     test_type: str = Field(..., description="Type of test: imagepull, crashloop, or oom")
@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Sources of audit entries
+const (
+	SourceWatcher  = "watcher"  // fix attempts made or reported by the pod watcher
+	SourceExecutor = "executor" // kubectl command batches run by the HTTP executor
+)
+
+// Entry records one fix attempt
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Source    string    `json:"source"`
+	Cluster   string    `json:"cluster,omitempty"`
+	Namespace string    `json:"namespace"`
+	PodName   string    `json:"pod_name"`
+	PodUID    string    `json:"pod_uid,omitempty"`
+	ErrorType string    `json:"error_type"`
+	Strategy  string    `json:"strategy,omitempty"`
+	Commands  []string  `json:"commands,omitempty"`
+	Success   bool      `json:"success"`
+	DryRun    bool      `json:"dry_run"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// AuditLogger appends one JSON line per fix attempt to a file
+type AuditLogger struct {
+	file  *os.File
+	mutex sync.Mutex
+}
+
+// NewAuditLogger opens (or creates) the audit log at path for appending
+func NewAuditLogger(path string) (*AuditLogger, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", path, err)
+	}
+	return &AuditLogger{file: file}, nil
+}
+
+// Log appends an entry, stamping it with the current time if it has none
+func (a *AuditLogger) Log(entry Entry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	// One write per entry keeps lines intact when fixes are recorded concurrently
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if _, err := a.file.Write(line); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// Close flushes and closes the audit log
+func (a *AuditLogger) Close() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if err := a.file.Sync(); err != nil {
+		a.file.Close()
+		return err
+	}
+	return a.file.Close()
+}
@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// splitCommand splits a command line into arguments the way a POSIX shell would, without
+// expanding anything: single quotes keep their contents literally, double quotes allow
+// backslash-escaped quotes, backslashes, $ and `, and a backslash outside quotes escapes the
+// next character. This keeps arguments like note="fixed by agent" or -p '{"spec":{}}' intact.
+func splitCommand(command string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool // distinguishes an empty quoted argument from no argument
+		quote   rune // the open quote character, or 0
+		escaped bool
+	)
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			// Inside double quotes a backslash only escapes characters special there
+			if quote == '"' && !strings.ContainsRune("\"\\$`", r) {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inArg = true
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in command", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("command ends with an unfinished escape")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"kubectl get pods -n app", []string{"kubectl", "get", "pods", "-n", "app"}},
+		{"  kubectl\tget   pods\n", []string{"kubectl", "get", "pods"}},
+		{`kubectl annotate pod web note="fixed by agent"`, []string{"kubectl", "annotate", "pod", "web", "note=fixed by agent"}},
+		{`kubectl patch deploy web -p '{"spec":{"replicas":2}}'`, []string{"kubectl", "patch", "deploy", "web", "-p", `{"spec":{"replicas":2}}`}},
+		{`kubectl get pod web -o jsonpath='{.metadata.name}'`, []string{"kubectl", "get", "pod", "web", "-o", "jsonpath={.metadata.name}"}},
+		{`echo 'single \ keeps backslash'`, []string{"echo", `single \ keeps backslash`}},
+		{`echo "say \"hi\" \\ \$HOME \n"`, []string{"echo", `say "hi" \ $HOME \n`}},
+		{`echo one\ arg`, []string{"echo", "one arg"}},
+		{`echo \'literal\'`, []string{"echo", "'literal'"}},
+		{`echo '' ""`, []string{"echo", "", ""}},
+		{`echo pre"mid dle"post`, []string{"echo", "premid dlepost"}},
+		{"", nil},
+		{"   \t ", nil},
+	}
+
+	for _, tt := range tests {
+		got, err := splitCommand(tt.command)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tt.command, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestSplitCommandErrors(t *testing.T) {
+	for _, command := range []string{
+		`kubectl annotate pod web note="unterminated`,
+		`kubectl patch deploy web -p '{"spec":{}}`,
+		`kubectl get pods \`,
+	} {
+		if args, err := splitCommand(command); err == nil {
+			t.Errorf("%q: expected an error, got %q", command, args)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// protectedNamespace is never touched by generated commands
+const protectedNamespace = "kube-system"
+
+// namespaceResources are the resource names kubectl accepts for namespaces
+var namespaceResources = map[string]bool{"namespace": true, "namespaces": true, "ns": true}
+
+// clusterFlags point kubectl at another cluster or at other credentials than the agent's own
+var clusterFlags = map[string]bool{
+	"--context": true, "--cluster": true, "--kubeconfig": true, "--server": true, "-s": true,
+	"--user": true, "--as": true, "--as-group": true, "--as-uid": true, "--token": true,
+}
+
+// deniedVerbs run arbitrary code in containers, open connections into the cluster, or act
+// on nodes and certificates rather than on namespaced workloads
+var deniedVerbs = map[string]bool{
+	"exec": true, "attach": true, "cp": true, "debug": true, "proxy": true, "port-forward": true,
+	"cordon": true, "uncordon": true, "drain": true, "taint": true, "certificate": true,
+}
+
+// clusterScopedResources are the resource names of cluster-scoped kinds, which a namespace
+// flag cannot confine
+var clusterScopedResources = map[string]bool{
+	"node": true, "nodes": true, "no": true,
+	"namespace": true, "namespaces": true, "ns": true,
+	"clusterrole": true, "clusterroles": true,
+	"clusterrolebinding": true, "clusterrolebindings": true,
+	"persistentvolume": true, "persistentvolumes": true, "pv": true,
+	"storageclass": true, "storageclasses": true, "sc": true,
+	"customresourcedefinition": true, "customresourcedefinitions": true, "crd": true, "crds": true,
+	"priorityclass": true, "priorityclasses": true, "pc": true,
+	"mutatingwebhookconfiguration": true, "mutatingwebhookconfigurations": true,
+	"validatingwebhookconfiguration": true, "validatingwebhookconfigurations": true,
+	"apiservice": true, "apiservices": true,
+	"certificatesigningrequest": true, "certificatesigningrequests": true, "csr": true,
+	"runtimeclass": true, "runtimeclasses": true,
+	"ingressclass": true, "ingressclasses": true,
+	"volumeattachment": true, "volumeattachments": true,
+	"csidriver": true, "csidrivers": true, "csinode": true, "csinodes": true,
+}
+
+// manifestFlags read resources from a file or kustomization
+var manifestFlags = map[string]bool{"-f": true, "--filename": true, "-k": true, "--kustomize": true}
+
+// validateCommand checks a parsed command against the execution policy. Generated commands
+// must be plain kubectl invocations against the agent's own cluster and credentials. Namespace
+// flags must name the request's namespace, cluster-scoped kinds may not be named, and manifests
+// may only come from local files; the contents of those files are not inspected. Commands must
+// not run code in containers, open connections into the cluster, or delete namespaces or whole
+// collections of resources.
+func validateCommand(parts []string, namespace string) error {
+	if len(parts) == 0 {
+		return fmt.Errorf("%w: empty command", ErrBlockedByPolicy)
+	}
+	if parts[0] != "kubectl" {
+		return fmt.Errorf("%w: only kubectl commands may be executed, got %q", ErrBlockedByPolicy, parts[0])
+	}
+
+	var positional []string
+	args := parts[1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if strings.Contains(arg, protectedNamespace) {
+			return fmt.Errorf("%w: commands may not touch %s", ErrBlockedByPolicy, protectedNamespace)
+		}
+		if flag := strings.SplitN(arg, "=", 2)[0]; clusterFlags[flag] {
+			return fmt.Errorf("%w: %s may not be set; commands run against the agent's own cluster and credentials", ErrBlockedByPolicy, flag)
+		}
+
+		if flag, value, hasValue := strings.Cut(arg, "="); manifestFlags[flag] {
+			if !hasValue {
+				if i+1 >= len(args) {
+					return fmt.Errorf("%w: %s requires a value", ErrBlockedByPolicy, arg)
+				}
+				i++
+				value = args[i]
+			}
+			if err := checkManifestSource(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch {
+		case arg == "-A" || arg == "--all-namespaces" || strings.HasPrefix(arg, "--all-namespaces="):
+			return fmt.Errorf("%w: commands may not span all namespaces", ErrBlockedByPolicy)
+		case arg == "-n" || arg == "--namespace":
+			if i+1 >= len(args) {
+				return fmt.Errorf("%w: %s requires a value", ErrBlockedByPolicy, arg)
+			}
+			i++
+			if err := checkNamespace(args[i], namespace); err != nil {
+				return err
+			}
+		case strings.HasPrefix(arg, "--namespace="), strings.HasPrefix(arg, "-n="):
+			if err := checkNamespace(arg[strings.Index(arg, "=")+1:], namespace); err != nil {
+				return err
+			}
+		case strings.HasPrefix(arg, "-n") && !strings.HasPrefix(arg, "--"):
+			if err := checkNamespace(strings.TrimPrefix(arg, "-n"), namespace); err != nil {
+				return err
+			}
+		case strings.HasPrefix(arg, "-"):
+			// Flags that change the target cluster or credentials were rejected above
+		default:
+			positional = append(positional, arg)
+		}
+	}
+
+	if len(positional) > 0 && deniedVerbs[positional[0]] {
+		return fmt.Errorf("%w: kubectl %s is not allowed", ErrBlockedByPolicy, positional[0])
+	}
+
+	// As for delete below, flag values may sit among the positional arguments
+	if len(positional) > 1 {
+		for _, target := range positional[1:] {
+			for _, kind := range resourceKinds(target) {
+				if clusterScopedResources[kind] {
+					return fmt.Errorf("%w: cluster-scoped resource %s is not allowed", ErrBlockedByPolicy, kind)
+				}
+			}
+		}
+	}
+
+	if deleteAt := indexOf(positional, "delete"); deleteAt >= 0 {
+		// Flag values may sit among the positional arguments, so check everything after
+		// the verb rather than guessing which one is the resource type
+		for _, target := range positional[deleteAt+1:] {
+			resourceType := strings.ToLower(strings.SplitN(target, "/", 2)[0])
+			for _, kind := range strings.Split(resourceType, ",") {
+				if namespaceResources[kind] {
+					return fmt.Errorf("%w: deleting namespaces is not allowed", ErrBlockedByPolicy)
+				}
+			}
+		}
+		for _, arg := range args {
+			if arg == "--all" || strings.HasPrefix(arg, "--all=") {
+				return fmt.Errorf("%w: delete --all is not allowed", ErrBlockedByPolicy)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resourceKinds returns the resource names in a kubectl target such as "pods",
+// "deploy/web", "pods,services" or "clusterroles.rbac.authorization.k8s.io"
+func resourceKinds(target string) []string {
+	resourceType := strings.ToLower(strings.SplitN(target, "/", 2)[0])
+	var kinds []string
+	for _, kind := range strings.Split(resourceType, ",") {
+		kinds = append(kinds, strings.SplitN(kind, ".", 2)[0])
+	}
+	return kinds
+}
+
+// checkManifestSource rejects manifests read from stdin or a URL, whose contents are
+// not known when the command is checked
+func checkManifestSource(source string) error {
+	if source == "-" {
+		return fmt.Errorf("%w: manifests may not be read from stdin", ErrBlockedByPolicy)
+	}
+	if strings.Contains(source, "://") || strings.HasPrefix(source, "github.com/") {
+		return fmt.Errorf("%w: remote manifest %s is not allowed", ErrBlockedByPolicy, source)
+	}
+	return nil
+}
+
+// hasNamespaceFlag reports whether a kubectl command sets its namespace explicitly
+func hasNamespaceFlag(parts []string) bool {
+	for _, arg := range parts {
+		if arg == "--" {
+			break
+		}
+		if arg == "-n" || arg == "--namespace" || strings.HasPrefix(arg, "--namespace=") ||
+			(strings.HasPrefix(arg, "-n") && !strings.HasPrefix(arg, "--")) {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeToNamespace adds the request's namespace to a command that doesn't set one, so it
+// cannot fall back to the kubeconfig's default namespace
+func scopeToNamespace(parts []string, namespace string) []string {
+	if namespace == "" || hasNamespaceFlag(parts) {
+		return parts
+	}
+	scoped := make([]string, 0, len(parts)+2)
+	if end := indexOf(parts, "--"); end >= 0 {
+		scoped = append(scoped, parts[:end]...)
+		scoped = append(scoped, "-n", namespace)
+		return append(scoped, parts[end:]...)
+	}
+	scoped = append(scoped, parts...)
+	return append(scoped, "-n", namespace)
+}
+
+// indexOf returns the index of value in values, or -1
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkNamespace rejects a namespace flag that is protected or differs from the request's namespace
+func checkNamespace(value, namespace string) error {
+	if value == protectedNamespace {
+		return fmt.Errorf("%w: commands may not touch %s", ErrBlockedByPolicy, protectedNamespace)
+	}
+	if namespace != "" && value != namespace {
+		return fmt.Errorf("%w: command targets namespace %q but the request is for %q", ErrBlockedByPolicy, value, namespace)
+	}
+	return nil
+}
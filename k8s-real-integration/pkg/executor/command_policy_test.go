@@ -0,0 +1,87 @@
+package executor
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		allowed bool
+	}{
+		{"kubectl get pods -n app", true},
+		{"kubectl delete pod web-1 -n app", true},
+		{"kubectl rollout restart deployment/web --namespace=app", true},
+		{"kubectl get pods", true},
+		{"helm uninstall web", false},
+		{"kubectl get pods -n kube-system", false},
+		{"kubectl get pods -A", false},
+		{"kubectl get pods -n other", false},
+		{"kubectl delete namespace app", false},
+		{"kubectl delete pods --all -n app", false},
+		{"kubectl --context prod delete pod x -n app", false},
+		{"kubectl delete pod x -n app --context=prod", false},
+		{"kubectl delete pod x -n app --kubeconfig=/tmp/other", false},
+		{"kubectl delete pod x -n app --server=https://10.0.0.1", false},
+		{"kubectl delete pod x -n app -s https://10.0.0.1", false},
+		{"kubectl delete pod x -n app --cluster other", false},
+		{"kubectl delete pod x -n app --as admin", false},
+		{"kubectl delete pod x -n app --token abc", false},
+		{"kubectl exec x -n app -- rm -rf /", false},
+		{"kubectl cp x:/etc/passwd ./passwd -n app", false},
+		{"kubectl proxy", false},
+		{"kubectl port-forward pod/x 8080:80 -n app", false},
+		{"kubectl create clusterrolebinding x --clusterrole=cluster-admin --serviceaccount=app:default", false},
+		{"kubectl delete node worker-1", false},
+		{"kubectl get nodes,pods -n app", false},
+		{"kubectl patch clusterroles.rbac.authorization.k8s.io/view -p {} -n app", false},
+		{"kubectl drain worker-1 --ignore-daemonsets", false},
+		{"kubectl apply -f https://example.com/fix.yaml -n app", false},
+		{"kubectl apply --filename=http://example.com/fix.yaml -n app", false},
+		{"kubectl apply -f - -n app", false},
+		{"kubectl apply -k github.com/example/fix -n app", false},
+		{"kubectl apply -f /tmp/fix.yaml -n app", true},
+		{"kubectl get pods -o yaml -n app", true},
+	}
+
+	for _, tt := range tests {
+		err := validateCommand(strings.Fields(tt.command), "app")
+		if tt.allowed && err != nil {
+			t.Errorf("%q: unexpected error %v", tt.command, err)
+		}
+		if !tt.allowed {
+			if err == nil {
+				t.Errorf("%q: expected the command to be refused", tt.command)
+			} else if !errors.Is(err, ErrBlockedByPolicy) {
+				t.Errorf("%q: error %v does not wrap ErrBlockedByPolicy", tt.command, err)
+			}
+		}
+	}
+}
+
+func TestScopeToNamespace(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"kubectl get pods", "kubectl get pods -n app"},
+		{"kubectl get pods -n app", "kubectl get pods -n app"},
+		{"kubectl get pods --namespace=app", "kubectl get pods --namespace=app"},
+		{"kubectl get pods -napp", "kubectl get pods -napp"},
+		{"kubectl annotate pod x a=b -- extra", "kubectl annotate pod x a=b -n app -- extra"},
+	}
+
+	for _, tt := range tests {
+		got := scopeToNamespace(strings.Fields(tt.command), "app")
+		if want := strings.Fields(tt.want); !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %q, want %q", tt.command, got, want)
+		}
+	}
+
+	if got := scopeToNamespace([]string{"kubectl", "get", "nodes"}, ""); len(got) != 3 {
+		t.Errorf("empty namespace should leave the command unchanged, got %q", got)
+	}
+}
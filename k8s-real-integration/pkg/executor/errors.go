@@ -0,0 +1,17 @@
+package executor
+
+import "errors"
+
+// Failure classes returned (wrapped) by the executor; test them with errors.Is
+var (
+	// ErrKubectlNotFound means the kubectl binary could not be run
+	ErrKubectlNotFound = errors.New("kubectl is not available")
+	// ErrClusterUnreachable means kubectl could not reach the cluster of its current context
+	ErrClusterUnreachable = errors.New("kubectl cannot reach the cluster")
+	// ErrPodStatusUnavailable means the status of a pod could not be read after a fix
+	ErrPodStatusUnavailable = errors.New("pod status unavailable")
+	// ErrValidationTimeout means a fixed pod did not become ready in time
+	ErrValidationTimeout = errors.New("pod did not become ready within timeout")
+	// ErrBlockedByPolicy is returned for commands the executor refuses to run
+	ErrBlockedByPolicy = errors.New("blocked by policy")
+)
@@ -47,11 +47,38 @@ func NewKubectlExecutor(dryRun bool, timeout time.Duration) *KubectlExecutor {
 	}
 }
 
+// execCommand starts a command; tests replace it to observe what would be run
+var execCommand = exec.CommandContext
+
+// dryRunKey marks a context whose commands must only be logged
+type dryRunKey struct{}
+
+// WithDryRun returns a context under which commands are logged but never executed,
+// regardless of the executor's own dry-run setting
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether commands run under ctx must not change the cluster
+func (e *KubectlExecutor) IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return e.dryRun || dryRun
+}
+
 // ExecuteCommands executes a list of kubectl commands in sequence
 func (e *KubectlExecutor) ExecuteCommands(ctx context.Context, commands []string, podName, namespace, errorType string) (*ExecutionReport, error) {
+	return e.ExecuteCommandsWithTimeout(ctx, commands, e.timeout, podName, namespace, errorType)
+}
+
+// ExecuteCommandsWithTimeout executes commands in sequence, bounding each command by
+// commandTimeout (capped at the executor's timeout) in addition to the batch context
+func (e *KubectlExecutor) ExecuteCommandsWithTimeout(ctx context.Context, commands []string, commandTimeout time.Duration, podName, namespace, errorType string) (*ExecutionReport, error) {
+	if commandTimeout <= 0 || commandTimeout > e.timeout {
+		commandTimeout = e.timeout
+	}
 	startTime := time.Now()
 
-	log.Printf("🔧 Starting kubectl command execution for pod: %s (dry-run: %v)", podName, e.dryRun)
+	log.Printf("🔧 Starting kubectl command execution for pod: %s (dry-run: %v)", podName, e.IsDryRun(ctx))
 
 	report := &ExecutionReport{
 		PodName:       podName,
@@ -66,7 +93,7 @@ func (e *KubectlExecutor) ExecuteCommands(ctx context.Context, commands []string
 	for i, command := range commands {
 		log.Printf("📋 Executing command %d/%d: %s", i+1, len(commands), command)
 
-		result := e.executeCommand(ctx, command, podName, namespace)
+		result := e.executeCommand(ctx, command, commandTimeout, podName, namespace)
 		report.Commands = append(report.Commands, result)
 
 		if result.Success {
@@ -133,7 +160,7 @@ func (e *KubectlExecutor) ExecuteCommands(ctx context.Context, commands []string
 }
 
 // executeCommand executes a single kubectl command
-func (e *KubectlExecutor) executeCommand(ctx context.Context, command, podName, namespace string) CommandResult {
+func (e *KubectlExecutor) executeCommand(ctx context.Context, command string, timeout time.Duration, podName, namespace string) CommandResult {
 	startTime := time.Now()
 
 	result := CommandResult{
@@ -145,8 +172,30 @@ func (e *KubectlExecutor) executeCommand(ctx context.Context, command, podName,
 	// Log command execution
 	log.Printf("🔄 Executing: %s", command)
 
+	// Parse command, keeping quoted arguments together
+	parts, err := splitCommand(command)
+	if err != nil {
+		result.Error = err.Error()
+		result.Duration = time.Since(startTime).String()
+		return result
+	}
+	if len(parts) == 0 {
+		result.Error = "Empty command"
+		result.Duration = time.Since(startTime).String()
+		return result
+	}
+
+	// Refuse commands outside the execution policy, even in dry-run
+	if err := validateCommand(parts, namespace); err != nil {
+		result.Error = err.Error()
+		result.Duration = time.Since(startTime).String()
+		log.Printf("🛡️  Refused command: %s | %v", command, err)
+		return result
+	}
+	parts = scopeToNamespace(parts, namespace)
+
 	// Handle dry-run mode
-	if e.dryRun {
+	if e.IsDryRun(ctx) {
 		result.Output = fmt.Sprintf("DRY-RUN: Would execute: %s", command)
 		result.Success = true
 		result.Duration = time.Since(startTime).String()
@@ -155,17 +204,9 @@ func (e *KubectlExecutor) executeCommand(ctx context.Context, command, podName,
 	}
 
 	// Create execution context with timeout
-	execCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	execCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Parse command
-	parts := strings.Fields(command)
-	if len(parts) == 0 {
-		result.Error = "Empty command"
-		result.Duration = time.Since(startTime).String()
-		return result
-	}
-
 	// Handle watch commands that can hang indefinitely
 	if strings.Contains(command, "-w") || strings.Contains(command, "--watch") {
 		// Remove watch flag and add timeout
@@ -180,7 +221,7 @@ func (e *KubectlExecutor) executeCommand(ctx context.Context, command, podName,
 	}
 
 	// Execute command
-	cmd := exec.CommandContext(execCtx, parts[0], parts[1:]...)
+	cmd := execCommand(execCtx, parts[0], parts[1:]...)
 	cmd.Env = os.Environ()
 
 	output, err := cmd.CombinedOutput()
@@ -215,7 +256,7 @@ func (e *KubectlExecutor) ValidateKubernetesConnection() error {
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
-		return fmt.Errorf("kubectl cluster connection failed: %v\nOutput: %s", err, string(output))
+		return fmt.Errorf("%w: %v\nOutput: %s", ErrClusterUnreachable, err, string(output))
 	}
 
 	log.Printf("✅ kubectl cluster connection validated")
@@ -228,7 +269,7 @@ func (e *KubectlExecutor) GetPodStatus(podName, namespace string) (string, error
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
-		return "", fmt.Errorf("failed to get pod status: %v", err)
+		return "", fmt.Errorf("%w: %v", ErrPodStatusUnavailable, err)
 	}
 
 	return strings.TrimSpace(string(output)), nil
@@ -244,7 +285,7 @@ func (e *KubectlExecutor) WaitForPodReady(podName, namespace string, timeout tim
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
-		return fmt.Errorf("pod did not become ready within timeout: %v\nOutput: %s", err, string(output))
+		return fmt.Errorf("%w: %v\nOutput: %s", ErrValidationTimeout, err, string(output))
 	}
 
 	log.Printf("✅ Pod %s is now ready", podName)
@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordExec replaces execCommand for the test and returns the commands it was asked to run.
+// The commands run as `true` so nothing touches a cluster.
+func recordExec(t *testing.T) *[]string {
+	t.Helper()
+	var started []string
+	original := execCommand
+	execCommand = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		started = append(started, strings.Join(append([]string{name}, args...), " "))
+		return exec.CommandContext(ctx, "true")
+	}
+	t.Cleanup(func() { execCommand = original })
+	return &started
+}
+
+func TestDryRunContextNeverExecutes(t *testing.T) {
+	started := recordExec(t)
+	e := NewKubectlExecutor(false, time.Minute)
+
+	report, err := e.ExecuteCommands(WithDryRun(context.Background()),
+		[]string{"kubectl delete pod web-1 -n app", "kubectl rollout restart deployment/web -n app"},
+		"web-1", "app", "CrashLoopBackOff")
+	if err != nil {
+		t.Fatalf("ExecuteCommands: %v", err)
+	}
+
+	if len(*started) != 0 {
+		t.Errorf("dry-run context started commands: %q", *started)
+	}
+	if report.SuccessCount != 2 {
+		t.Errorf("SuccessCount = %d, want 2", report.SuccessCount)
+	}
+	for _, result := range report.Commands {
+		if !strings.HasPrefix(result.Output, "DRY-RUN") {
+			t.Errorf("%q: output %q is not a dry-run note", result.Command, result.Output)
+		}
+	}
+}
+
+func TestDryRunExecutorNeverExecutes(t *testing.T) {
+	started := recordExec(t)
+	e := NewKubectlExecutor(true, time.Minute)
+
+	if _, err := e.ExecuteCommands(context.Background(), []string{"kubectl delete pod web-1 -n app"}, "web-1", "app", "OOMKilled"); err != nil {
+		t.Fatalf("ExecuteCommands: %v", err)
+	}
+	if len(*started) != 0 {
+		t.Errorf("dry-run executor started commands: %q", *started)
+	}
+}
+
+func TestExecuteRunsScopedCommand(t *testing.T) {
+	started := recordExec(t)
+	e := NewKubectlExecutor(false, time.Minute)
+
+	if _, err := e.ExecuteCommands(context.Background(), []string{"kubectl delete pod web-1"}, "web-1", "app", "OOMKilled"); err != nil {
+		t.Fatalf("ExecuteCommands: %v", err)
+	}
+	if want := []string{"kubectl delete pod web-1 -n app"}; len(*started) != 1 || (*started)[0] != want[0] {
+		t.Errorf("started %q, want %q", *started, want)
+	}
+}
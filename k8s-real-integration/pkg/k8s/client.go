@@ -1,15 +1,21 @@
 package k8s
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -18,8 +24,10 @@ import (
 
 // Client wraps Kubernetes client functionality
 type Client struct {
-	clientset *kubernetes.Clientset
-	config    *rest.Config
+	clientset      kubernetes.Interface
+	config         *rest.Config
+	connectTimeout time.Duration
+	dryRun         bool
 }
 
 // NewClient creates a new Kubernetes client
@@ -46,6 +54,43 @@ func NewClient() (*Client, error) {
 	}, nil
 }
 
+// NewClientForContext creates a Kubernetes client for a named kubeconfig context
+func NewClientForContext(contextName string) (*Client, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: contextName}
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig context %s: %w", contextName, err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset for context %s: %w", contextName, err)
+	}
+
+	return &Client{
+		clientset: clientset,
+		config:    config,
+	}, nil
+}
+
+// CurrentContext returns the current context of the default kubeconfig, which is the
+// cluster kubectl and the reflexion service act on
+func CurrentContext() (string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).RawConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return config.CurrentContext, nil
+}
+
+// NewClientFromClientset wraps an existing clientset, such as a fake one in tests
+func NewClientFromClientset(clientset kubernetes.Interface) *Client {
+	return &Client{clientset: clientset}
+}
+
 // getKubeConfig gets the kubeconfig from default locations
 func getKubeConfig() (*rest.Config, error) {
 	var kubeconfig string
@@ -79,22 +124,42 @@ func getKubeConfig() (*rest.Config, error) {
 
 // TestConnection tests the connection to Kubernetes cluster
 func (c *Client) TestConnection() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	timeout := c.connectTimeout
+	if timeout <= 0 {
+		timeout = DefaultConnectTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	// Try to get cluster info
-	_, err := c.clientset.CoreV1().Namespaces().Get(ctx, "default", metav1.GetOptions{})
+	// Ask for the server version; unlike reading a namespace this needs no RBAC grants
+	_, err := c.clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
 	if err != nil {
-		return fmt.Errorf("failed to connect to cluster: %w", err)
+		return fmt.Errorf("failed to connect to cluster: %w", classifyConnectionError(err, timeout))
 	}
 
 	log.Printf("✅ Successfully connected to Kubernetes cluster")
 	return nil
 }
 
+// WatchPods opens a watch stream on pods in a namespace starting after resourceVersion;
+// the server closes it after timeout
+func (c *Client) WatchPods(ctx context.Context, namespace, resourceVersion string, timeout time.Duration) (watch.Interface, error) {
+	timeoutSeconds := int64(timeout.Seconds())
+	podWatch, err := c.clientset.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{
+		ResourceVersion: resourceVersion,
+		TimeoutSeconds:  &timeoutSeconds,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch pods in namespace %s: %w", namespace, err)
+	}
+
+	return podWatch, nil
+}
+
 // GetPod retrieves a pod by name and namespace
-func (c *Client) GetPod(namespace, name string) (*v1.Pod, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func (c *Client) GetPod(ctx context.Context, namespace, name string) (*v1.Pod, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -106,8 +171,8 @@ func (c *Client) GetPod(namespace, name string) (*v1.Pod, error) {
 }
 
 // ListPods lists all pods in a namespace
-func (c *Client) ListPods(namespace string) (*v1.PodList, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func (c *Client) ListPods(ctx context.Context, namespace string) (*v1.PodList, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
@@ -118,9 +183,61 @@ func (c *Client) ListPods(namespace string) (*v1.PodList, error) {
 	return pods, nil
 }
 
+// GetReplicaSet retrieves a ReplicaSet by name and namespace
+func (c *Client) GetReplicaSet(ctx context.Context, namespace, name string) (*appsv1.ReplicaSet, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	replicaSet, err := c.clientset.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replica set %s/%s: %w", namespace, name, err)
+	}
+
+	return replicaSet, nil
+}
+
+// GetConfigMap retrieves a ConfigMap by name and namespace
+func (c *Client) GetConfigMap(ctx context.Context, namespace, name string) (*v1.ConfigMap, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	configMap, err := c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config map %s/%s: %w", namespace, name, err)
+	}
+
+	return configMap, nil
+}
+
+// GetSecret retrieves a Secret by name and namespace
+func (c *Client) GetSecret(ctx context.Context, namespace, name string) (*v1.Secret, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+	}
+
+	return secret, nil
+}
+
+// ListLimitRanges lists the LimitRanges in a namespace
+func (c *Client) ListLimitRanges(ctx context.Context, namespace string) ([]v1.LimitRange, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	limitRanges, err := c.clientset.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list limit ranges in namespace %s: %w", namespace, err)
+	}
+
+	return limitRanges.Items, nil
+}
+
 // GetPodEvents retrieves events for a specific pod
-func (c *Client) GetPodEvents(namespace, podName string) ([]v1.Event, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func (c *Client) GetPodEvents(ctx context.Context, namespace, podName string) ([]v1.Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	// Get events related to the pod
@@ -134,31 +251,187 @@ func (c *Client) GetPodEvents(namespace, podName string) ([]v1.Event, error) {
 	return events.Items, nil
 }
 
-// GetPodLogs retrieves logs for a specific pod
-func (c *Client) GetPodLogs(namespace, podName string) ([]string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+// LogOptions bounds how much log output is fetched for a pod
+type LogOptions struct {
+	TailLines    int64     // last N lines; 0 means DefaultLogOptions.TailLines, capped at MaxTailLines
+	SinceTime    time.Time // only lines after this time; zero means no limit
+	SinceSeconds int64     // only lines from the last N seconds; ignored when SinceTime is set
+	MaxBytes     int64     // hard cap on bytes read; 0 means DefaultLogOptions.MaxBytes
+	Previous     bool      // read the previous, terminated instance of the container
+}
+
+// MaxTailLines caps LogOptions.TailLines so a large request cannot exhaust the agent's memory
+const MaxTailLines = 10000
+
+// DefaultLogOptions is used for fields left unset in LogOptions
+var DefaultLogOptions = LogOptions{
+	TailLines: 50,
+	MaxBytes:  64 * 1024,
+}
+
+// podLogOptions converts LogOptions to the API's log options, applying defaults
+func (o LogOptions) podLogOptions() *v1.PodLogOptions {
+	if o.TailLines <= 0 {
+		o.TailLines = DefaultLogOptions.TailLines
+	}
+	if o.TailLines > MaxTailLines {
+		o.TailLines = MaxTailLines
+	}
+	if o.MaxBytes <= 0 {
+		o.MaxBytes = DefaultLogOptions.MaxBytes
+	}
+
+	options := &v1.PodLogOptions{
+		TailLines:  int64Ptr(o.TailLines),
+		LimitBytes: int64Ptr(o.MaxBytes),
+		Previous:   o.Previous,
+	}
+	if !o.SinceTime.IsZero() {
+		sinceTime := metav1.NewTime(o.SinceTime)
+		options.SinceTime = &sinceTime
+	} else if o.SinceSeconds > 0 {
+		options.SinceSeconds = int64Ptr(o.SinceSeconds)
+	}
+	return options
+}
+
+// GetPodLogs retrieves logs for a container of a pod, bounded by opts. An empty containerName
+// selects the pod's first container.
+func (c *Client) GetPodLogs(ctx context.Context, namespace, podName, containerName string, opts LogOptions) ([]string, error) {
+	pod, err := c.GetPod(ctx, namespace, podName)
+	if err != nil {
+		return nil, err
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("pod %s/%s has no containers", namespace, podName)
+	}
+	if containerName == "" {
+		containerName = pod.Spec.Containers[0].Name
+	} else if !hasContainer(pod, containerName) {
+		return nil, fmt.Errorf("container %q not found in pod %s/%s", containerName, namespace, podName)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	// Get pod logs
-	req := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, &v1.PodLogOptions{
-		TailLines: int64Ptr(50), // Get last 50 lines
-	})
+	logOptions := opts.podLogOptions()
+	logOptions.Container = containerName
+	req := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, logOptions)
 
 	logs, err := req.Stream(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get logs for pod %s/%s: %w", namespace, podName, err)
+		return nil, fmt.Errorf("failed to get logs for container %s of pod %s/%s: %w", containerName, namespace, podName, err)
 	}
 	defer logs.Close()
 
-	// Read logs (simplified - in production, use a proper log reader)
-	logLines := []string{
-		"Pod logs would be read here",
-		"This is a placeholder for actual log reading",
+	var logLines []string
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		logLines = append(logLines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return logLines, fmt.Errorf("failed to read logs for container %s of pod %s/%s: %w", containerName, namespace, podName, err)
 	}
 
 	return logLines, nil
 }
 
+// hasContainer reports whether the pod has an init or app container with the given name
+func hasContainer(pod *v1.Pod, name string) bool {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == name {
+			return true
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetDryRun makes every mutating call a server-side dry run that changes nothing
+func (c *Client) SetDryRun(dryRun bool) {
+	c.dryRun = dryRun
+}
+
+// DryRun reports whether mutating calls are server-side dry runs
+func (c *Client) DryRun() bool {
+	return c.dryRun
+}
+
+// dryRunOption returns the DryRun option for mutating API calls
+func (c *Client) dryRunOption() []string {
+	if c.dryRun {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// DeletePod deletes a pod by name and namespace
+func (c *Client) DeletePod(ctx context.Context, namespace, name string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := c.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{DryRun: c.dryRunOption()}); err != nil {
+		return fmt.Errorf("failed to delete pod %s/%s: %w", namespace, name, err)
+	}
+
+	return nil
+}
+
+// CordonNode marks a node unschedulable and records the reason as an annotation
+func (c *Client) CordonNode(ctx context.Context, nodeName, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{"k8s-reflexion/cordon-reason": reason},
+		},
+		"spec": map[string]interface{}{"unschedulable": true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cordon patch: %w", err)
+	}
+
+	_, err = c.clientset.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{DryRun: c.dryRunOption()})
+	if err != nil {
+		return fmt.Errorf("failed to cordon node %s: %w", nodeName, err)
+	}
+
+	return nil
+}
+
+// SetPodFinalizers replaces a pod's finalizers. The resource version guards against
+// overwriting finalizers added since the pod was read.
+func (c *Client) SetPodFinalizers(ctx context.Context, namespace, name, resourceVersion string, finalizers []string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if finalizers == nil {
+		finalizers = []string{}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": resourceVersion,
+			"finalizers":      finalizers,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal finalizer patch: %w", err)
+	}
+
+	_, err = c.clientset.CoreV1().Pods(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{DryRun: c.dryRunOption()})
+	if err != nil {
+		return fmt.Errorf("failed to update finalizers of pod %s/%s: %w", namespace, name, err)
+	}
+
+	return nil
+}
+
 // IsPodFailed checks if a pod has failed or is in problematic state
 func (c *Client) IsPodFailed(pod *v1.Pod) bool {
 	// Check pod phase
@@ -210,8 +483,18 @@ func (c *Client) IsPodFailed(pod *v1.Pod) bool {
 	return false
 }
 
+// IsEvicted reports whether a pod was evicted by the kubelet, e.g. because of node pressure
+func IsEvicted(pod *v1.Pod) bool {
+	return pod.Status.Phase == v1.PodFailed && pod.Status.Reason == "Evicted"
+}
+
 // GetPodErrorType determines the type of error for a failed pod
 func (c *Client) GetPodErrorType(pod *v1.Pod) string {
+	// Evicted pods are dead for good; their containers' exit codes say nothing useful
+	if IsEvicted(pod) {
+		return "Evicted"
+	}
+
 	// Check if pod is stuck in Pending state
 	if pod.Status.Phase == v1.PodPending {
 		if time.Since(pod.CreationTimestamp.Time) > 60*time.Second {
@@ -237,6 +520,11 @@ func (c *Client) GetPodErrorType(pod *v1.Pod) string {
 		}
 	}
 
+	// Images without an entrypoint fail with a cryptic runtime error; name it explicitly
+	if _, found := FindNoCommandContainer(pod); found {
+		return "NoCommandSpecified"
+	}
+
 	// Check container states for specific errors
 	for _, containerStatus := range pod.Status.ContainerStatuses {
 		// PRIORITY: Check Terminated state first for OOMKilled
@@ -271,6 +559,11 @@ func (c *Client) GetPodErrorType(pod *v1.Pod) string {
 			case "ImagePullBackOff", "ErrImagePull":
 				return "ImagePullBackOff"
 			case "CrashLoopBackOff":
+				// A crash loop started by an OOM kill is a memory problem, not a generic crash:
+				// the previous termination is the root cause, so it takes precedence
+				if isOOMTermination(containerStatus.LastTerminationState.Terminated) {
+					return "OOMKilled"
+				}
 				return "CrashLoopBackOff"
 			case "InvalidImageName":
 				return "InvalidImageName"
@@ -296,6 +589,39 @@ func (c *Client) GetPodErrorType(pod *v1.Pod) string {
 	return "Unknown"
 }
 
+// isOOMTermination reports whether a container termination was caused by the OOM killer
+func isOOMTermination(terminated *v1.ContainerStateTerminated) bool {
+	if terminated == nil {
+		return false
+	}
+	return terminated.Reason == "OOMKilled" || terminated.ExitCode == 137
+}
+
+// FindNoCommandContainer returns the first container that failed because its image
+// has no default command and none was specified in the pod spec
+func FindNoCommandContainer(pod *v1.Pod) (v1.ContainerStatus, bool) {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		var messages []string
+		if containerStatus.State.Waiting != nil {
+			messages = append(messages, containerStatus.State.Waiting.Message)
+		}
+		if containerStatus.State.Terminated != nil {
+			messages = append(messages, containerStatus.State.Terminated.Message)
+		}
+		if containerStatus.LastTerminationState.Terminated != nil {
+			messages = append(messages, containerStatus.LastTerminationState.Terminated.Message)
+		}
+
+		for _, message := range messages {
+			if strings.Contains(strings.ToLower(message), "no command specified") {
+				return containerStatus, true
+			}
+		}
+	}
+
+	return v1.ContainerStatus{}, false
+}
+
 // Helper function
 func int64Ptr(i int64) *int64 {
 	return &i
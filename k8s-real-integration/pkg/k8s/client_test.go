@@ -0,0 +1,182 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// mutatorDryRunOptions runs every mutating client call against a fake clientset and
+// returns the DryRun option each one sent, by verb
+func mutatorDryRunOptions(t *testing.T, dryRun bool) map[string][]string {
+	t.Helper()
+	clientset := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "app", Finalizers: []string{"example.com/hold"}}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "app"}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+	)
+	client := NewClientFromClientset(clientset)
+	client.SetDryRun(dryRun)
+
+	ctx := context.Background()
+	if err := client.SetPodFinalizers(ctx, "app", "web-1", "", nil); err != nil {
+		t.Fatalf("SetPodFinalizers: %v", err)
+	}
+	if err := client.CordonNode(ctx, "node-1", "OOM kills"); err != nil {
+		t.Fatalf("CordonNode: %v", err)
+	}
+	if err := client.DeletePod(ctx, "app", "web-2"); err != nil {
+		t.Fatalf("DeletePod: %v", err)
+	}
+
+	options := make(map[string][]string)
+	for _, action := range clientset.Actions() {
+		switch action := action.(type) {
+		case k8stesting.DeleteAction:
+			options["delete "+action.GetResource().Resource] = action.GetDeleteOptions().DryRun
+		case k8stesting.PatchActionImpl:
+			options["patch "+action.GetResource().Resource] = action.GetPatchOptions().DryRun
+		}
+	}
+	return options
+}
+
+func TestMutatorsSendServerSideDryRun(t *testing.T) {
+	want := map[string][]string{
+		"patch pods":  {metav1.DryRunAll},
+		"patch nodes": {metav1.DryRunAll},
+		"delete pods": {metav1.DryRunAll},
+	}
+	if got := mutatorDryRunOptions(t, true); !reflect.DeepEqual(got, want) {
+		t.Errorf("dry-run options = %v, want %v", got, want)
+	}
+}
+
+func TestMutatorsWithoutDryRun(t *testing.T) {
+	options := mutatorDryRunOptions(t, false)
+	if len(options) != 3 {
+		t.Fatalf("expected 3 mutating calls, got %v", options)
+	}
+	for verb, dryRun := range options {
+		if len(dryRun) != 0 {
+			t.Errorf("%s sent DryRun %v without dry-run mode", verb, dryRun)
+		}
+	}
+}
+
+// waiting returns a container status waiting with reason
+func waiting(reason string) v1.ContainerStatus {
+	return v1.ContainerStatus{Name: "app", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: reason}}}
+}
+
+// terminated returns a container status terminated with reason and exit code
+func terminated(reason string, exitCode int32) v1.ContainerStatus {
+	return v1.ContainerStatus{Name: "app", State: v1.ContainerState{
+		Terminated: &v1.ContainerStateTerminated{Reason: reason, ExitCode: exitCode},
+	}}
+}
+
+func TestGetPodErrorType(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   v1.PodStatus
+		expected string
+	}{
+		{"waiting ImagePullBackOff", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{waiting("ImagePullBackOff")}}, "ImagePullBackOff"},
+		{"waiting ErrImagePull", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{waiting("ErrImagePull")}}, "ImagePullBackOff"},
+		{"waiting CrashLoopBackOff", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{waiting("CrashLoopBackOff")}}, "CrashLoopBackOff"},
+		{"waiting InvalidImageName", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{waiting("InvalidImageName")}}, "InvalidImageName"},
+		{"waiting CreateContainerConfigError", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{waiting("CreateContainerConfigError")}}, "CreateContainerConfigError"},
+		{"waiting CreateContainerError", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{waiting("CreateContainerError")}}, "CreateContainerError"},
+		{"waiting ConfigError", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{waiting("ConfigError")}}, "ConfigError"},
+		{"waiting RunContainerError", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{waiting("RunContainerError")}}, "RunContainerError"},
+		{"waiting ContainerCannotRun", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{waiting("ContainerCannotRun")}}, "ContainerCannotRun"},
+		{"init ImagePullBackOff", v1.PodStatus{InitContainerStatuses: []v1.ContainerStatus{waiting("ImagePullBackOff")}}, "InitContainerImagePullBackOff"},
+		{"init InvalidImageName", v1.PodStatus{InitContainerStatuses: []v1.ContainerStatus{waiting("InvalidImageName")}}, "InitContainerInvalidImageName"},
+		{"init exited non-zero", v1.PodStatus{InitContainerStatuses: []v1.ContainerStatus{terminated("Error", 2)}}, "InitContainerFailed"},
+		{"terminated exit 1", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{terminated("Error", 1)}}, "CrashLoopBackOff"},
+		{"terminated exit 137", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{terminated("Error", 137)}}, "OOMKilled"},
+		{"terminated exit 139", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{terminated("Error", 139)}}, "Segfault"},
+		{"terminated exit 143", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{terminated("Error", 143)}}, "SIGTERM"},
+		{"terminated other exit code", v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{terminated("Error", 42)}}, "CrashLoopBackOff"},
+	}
+
+	client := &Client{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "app", CreationTimestamp: metav1.Now()},
+				Status:     tt.status,
+			}
+			if got := client.GetPodErrorType(pod); got != tt.expected {
+				t.Errorf("GetPodErrorType() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetPodErrorTypeSpecialCases(t *testing.T) {
+	crashAfter := func(last v1.ContainerStateTerminated) v1.ContainerStatus {
+		status := waiting("CrashLoopBackOff")
+		status.LastTerminationState.Terminated = &last
+		return status
+	}
+	noCommand := waiting("CreateContainerError")
+	noCommand.State.Waiting.Message = "failed to create containerd container: No command specified"
+
+	tests := []struct {
+		name     string
+		age      time.Duration
+		status   v1.PodStatus
+		expected string
+	}{
+		{"evicted", 0, v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted",
+			ContainerStatuses: []v1.ContainerStatus{terminated("Error", 137)}}, "Evicted"},
+		{"pending for over a minute", 2 * time.Minute, v1.PodStatus{Phase: v1.PodPending}, "PodPending"},
+		{"pending briefly", 0, v1.PodStatus{Phase: v1.PodPending}, "Unknown"},
+		{"init container checked before containers", 0, v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{waiting("ErrImagePull")},
+			ContainerStatuses:     []v1.ContainerStatus{waiting("CrashLoopBackOff")}}, "InitContainerImagePullBackOff"},
+		{"init container exited cleanly", 0, v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{terminated("Completed", 0)},
+			ContainerStatuses:     []v1.ContainerStatus{waiting("ImagePullBackOff")}}, "ImagePullBackOff"},
+		{"no command specified", 0, v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{noCommand}}, "NoCommandSpecified"},
+		{"terminated OOMKilled reason", 0, v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{terminated("OOMKilled", 1)}}, "OOMKilled"},
+		{"terminated cleanly", 0, v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{terminated("Completed", 0)}}, "Unknown"},
+		{"crash loop after OOM kill", 0, v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+			crashAfter(v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137})}}, "OOMKilled"},
+		{"crash loop after exit 137", 0, v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+			crashAfter(v1.ContainerStateTerminated{Reason: "Error", ExitCode: 137})}}, "OOMKilled"},
+		{"crash loop after ordinary error", 0, v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+			crashAfter(v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1})}}, "CrashLoopBackOff"},
+		{"second container failing", 0, v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{
+			{Name: "sidecar", Ready: true, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			waiting("InvalidImageName")}}, "InvalidImageName"},
+		{"unhandled waiting reason", 0, v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{waiting("ContainerCreating")}}, "Unknown"},
+		{"failed phase without container detail", 0, v1.PodStatus{Phase: v1.PodFailed}, "PodFailed"},
+		{"running pod", 0, v1.PodStatus{Phase: v1.PodRunning}, "Unknown"},
+	}
+
+	client := &Client{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "web-1",
+					Namespace:         "app",
+					CreationTimestamp: metav1.NewTime(time.Now().Add(-tt.age)),
+				},
+				Status: tt.status,
+			}
+			if got := client.GetPodErrorType(pod); got != tt.expected {
+				t.Errorf("GetPodErrorType() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// DefaultConnectTimeout is used by TestConnection when no timeout is configured
+const DefaultConnectTimeout = 10 * time.Second
+
+// Connection failure categories returned (wrapped) by TestConnection
+var (
+	ErrConnectTimeout     = errors.New("timed out connecting to cluster")
+	ErrAuthFailed         = errors.New("cluster authentication failed")
+	ErrClusterUnreachable = errors.New("cluster unreachable")
+)
+
+// SetConnectTimeout sets how long TestConnection waits for the API server
+func (c *Client) SetConnectTimeout(timeout time.Duration) {
+	c.connectTimeout = timeout
+}
+
+// classifyConnectionError wraps a connection test error with its failure category
+// and a hint on how to resolve it
+func classifyConnectionError(err error, timeout time.Duration) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Errorf("%w after %v - the API server is slow or far away, try a larger --connect-timeout: %v",
+			ErrConnectTimeout, timeout, err)
+	case apierrors.IsUnauthorized(err):
+		return fmt.Errorf("%w - check the kubeconfig credentials or service account token: %v", ErrAuthFailed, err)
+	case apierrors.IsForbidden(err):
+		return fmt.Errorf("%w - credentials were accepted but lack permission to query the API server: %v", ErrAuthFailed, err)
+	default:
+		return fmt.Errorf("%w - check the API server address and network access: %v", ErrClusterUnreachable, err)
+	}
+}
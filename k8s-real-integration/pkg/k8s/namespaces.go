@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// serviceAccountNamespaceFile holds the namespace of the pod's service account when running in-cluster
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// AllNamespaces is the namespace value that selects every namespace
+const AllNamespaces = ""
+
+// canListPods checks whether pods in a namespace (AllNamespaces for cluster-wide) may be listed
+func (c *Client) canListPods(ctx context.Context, namespace string) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	return err
+}
+
+// listNamespaceNames returns the names of all namespaces
+func (c *Client) listNamespaceNames(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	namespaces, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(namespaces.Items))
+	for _, namespace := range namespaces.Items {
+		names = append(names, namespace.Name)
+	}
+	return names, nil
+}
+
+// DiscoverWatchableNamespaces returns the namespaces to watch when watching all namespaces.
+// With cluster-wide access this is just AllNamespaces. Under namespace-scoped RBAC it is every
+// namespace whose pods can be listed: the candidates come from listing namespaces or, if that
+// is forbidden too, from the service account's own namespace and fallback.
+func (c *Client) DiscoverWatchableNamespaces(ctx context.Context, fallback []string) ([]string, error) {
+	err := c.canListPods(ctx, AllNamespaces)
+	if err == nil {
+		return []string{AllNamespaces}, nil
+	}
+	if !apierrors.IsForbidden(err) {
+		return nil, fmt.Errorf("failed to list pods in all namespaces: %w", err)
+	}
+	log.Printf("🔒 Cluster-wide pod access is forbidden, discovering accessible namespaces")
+
+	candidates, err := c.listNamespaceNames(ctx)
+	if err != nil {
+		if !apierrors.IsForbidden(err) {
+			return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		}
+		log.Printf("🔒 Listing namespaces is forbidden, trying the service account namespace and --namespace")
+		candidates = fallback
+		if data, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+			candidates = append([]string{strings.TrimSpace(string(data))}, candidates...)
+		}
+	}
+
+	var accessible []string
+	seen := make(map[string]bool)
+	for _, namespace := range candidates {
+		if namespace == AllNamespaces || seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+
+		if err := c.canListPods(ctx, namespace); err != nil {
+			if !apierrors.IsForbidden(err) {
+				return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+			}
+			continue
+		}
+		accessible = append(accessible, namespace)
+	}
+
+	if len(accessible) == 0 {
+		return nil, fmt.Errorf("no namespace with permission to list pods was found")
+	}
+	return accessible, nil
+}
@@ -0,0 +1,41 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// rolloutPath is the API path of an Argo Rollout; there is no typed client for the CRD
+const rolloutPath = "/apis/argoproj.io/v1alpha1/namespaces/%s/rollouts/%s"
+
+// GetRolloutStableHash returns the pod template hash of an Argo Rollout's stable ReplicaSet
+func (c *Client) GetRolloutStableHash(ctx context.Context, namespace, name string) (string, error) {
+	restClient := c.clientset.Discovery().RESTClient()
+	if restClient == nil {
+		return "", fmt.Errorf("no REST client available to get rollout %s/%s", namespace, name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	body, err := restClient.Get().AbsPath(fmt.Sprintf(rolloutPath, namespace, name)).DoRaw(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get rollout %s/%s: %w", namespace, name, err)
+	}
+
+	var rollout struct {
+		Status struct {
+			StableRS string `json:"stableRS"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(body, &rollout); err != nil {
+		return "", fmt.Errorf("failed to decode rollout %s/%s: %w", namespace, name, err)
+	}
+	if rollout.Status.StableRS == "" {
+		return "", fmt.Errorf("rollout %s/%s has no stable ReplicaSet yet", namespace, name)
+	}
+
+	return rollout.Status.StableRS, nil
+}
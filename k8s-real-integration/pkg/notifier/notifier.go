@@ -0,0 +1,125 @@
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fix outcomes a notifier can be subscribed to
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	OutcomeManual  = "manual"
+)
+
+// Notification describes the outcome of one fix attempt
+type Notification struct {
+	Cluster   string `json:"cluster,omitempty"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	ErrorType string `json:"error_type"`
+	Action    string `json:"action,omitempty"`
+	Outcome   string `json:"outcome"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+	// Text is a human-readable summary; Slack incoming webhooks display this field
+	Text string `json:"text"`
+}
+
+// Notifier sends fix outcome notifications
+type Notifier interface {
+	Notify(n Notification) error
+}
+
+// ParseOutcomes parses a comma-separated list of outcomes (success, failure, manual)
+func ParseOutcomes(list string) (map[string]bool, error) {
+	outcomes := make(map[string]bool)
+	for _, outcome := range strings.Split(list, ",") {
+		outcome = strings.TrimSpace(outcome)
+		switch outcome {
+		case "":
+			continue
+		case OutcomeSuccess, OutcomeFailure, OutcomeManual:
+			outcomes[outcome] = true
+		default:
+			return nil, fmt.Errorf("invalid notification outcome %q (expected success, failure or manual)", outcome)
+		}
+	}
+	if len(outcomes) == 0 {
+		return nil, fmt.Errorf("no notification outcomes selected")
+	}
+	return outcomes, nil
+}
+
+// WebhookNotifier POSTs notifications as JSON to a URL, such as a Slack incoming webhook
+type WebhookNotifier struct {
+	url        string
+	notifyOn   map[string]bool
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a notifier that posts the selected outcomes to url
+func NewWebhookNotifier(url string, notifyOn map[string]bool) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:      url,
+		notifyOn: notifyOn,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Notify posts the notification unless its outcome is not selected
+func (w *WebhookNotifier) Notify(n Notification) error {
+	if !w.notifyOn[n.Outcome] {
+		return nil
+	}
+	if n.Text == "" {
+		n.Text = summary(n)
+	}
+
+	jsonData, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	resp, err := w.httpClient.Post(w.url, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to send notification to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// summary renders a one-line description of the notification
+func summary(n Notification) string {
+	pod := n.Namespace + "/" + n.Pod
+	if n.Cluster != "" {
+		pod = n.Cluster + ": " + pod
+	}
+
+	var text string
+	switch n.Outcome {
+	case OutcomeSuccess:
+		text = fmt.Sprintf(":white_check_mark: Fixed %s pod %s", n.ErrorType, pod)
+	case OutcomeManual:
+		text = fmt.Sprintf(":rotating_light: %s pod %s requires manual intervention", n.ErrorType, pod)
+	default:
+		text = fmt.Sprintf(":x: Failed to fix %s pod %s", n.ErrorType, pod)
+	}
+	if n.Action != "" {
+		text += fmt.Sprintf(" (action: %s)", n.Action)
+	}
+	if n.Message != "" {
+		text += " - " + n.Message
+	}
+	return text
+}
@@ -0,0 +1,76 @@
+package publisher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Publisher emits fix results to a message topic
+type Publisher interface {
+	Publish(v interface{}) error
+	Close()
+}
+
+// NewPublisher creates a publisher for the given URL; nats:// and tls:// URLs use NATS
+func NewPublisher(publishURL, subject string) (Publisher, error) {
+	u, err := url.Parse(publishURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid publish URL %s: %w", publishURL, err)
+	}
+
+	switch u.Scheme {
+	case "nats", "tls":
+		return NewNATSPublisher(publishURL, subject)
+	default:
+		return nil, fmt.Errorf("unsupported publish URL scheme %q (expected nats://)", u.Scheme)
+	}
+}
+
+// NATSPublisher publishes JSON messages to a NATS subject
+type NATSPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// NewNATSPublisher connects to a NATS server and publishes to subject
+func NewNATSPublisher(natsURL, subject string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(natsURL,
+		nats.Name("k8s-real-integration"),
+		nats.Timeout(10*time.Second),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", natsURL, err)
+	}
+
+	return &NATSPublisher{
+		conn:    conn,
+		subject: subject,
+	}, nil
+}
+
+// Publish marshals v to JSON and publishes it to the configured subject
+func (p *NATSPublisher) Publish(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	if err := p.conn.Publish(p.subject, data); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", p.subject, err)
+	}
+	return nil
+}
+
+// Close flushes pending messages and closes the connection
+func (p *NATSPublisher) Close() {
+	if err := p.conn.Flush(); err != nil {
+		p.conn.Close()
+		return
+	}
+	p.conn.Close()
+}
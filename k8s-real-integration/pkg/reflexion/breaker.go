@@ -0,0 +1,95 @@
+package reflexion
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the service while the circuit breaker is open
+var ErrCircuitOpen = errors.New("reflexion circuit breaker is open")
+
+// circuitBreaker stops calls to the service after too many consecutive failures, so an outage
+// costs one fast error per pod instead of a full round of timed-out retries
+type circuitBreaker struct {
+	mutex     sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool // a half-open probe request is in flight
+}
+
+// WithCircuitBreaker opens the circuit for cooldown after threshold consecutive failed
+// requests (0 disables the breaker). Once the cooldown has passed one request is let
+// through; its outcome closes the circuit or opens it again.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		if threshold > 0 && cooldown > 0 {
+			c.breaker = &circuitBreaker{threshold: threshold, cooldown: cooldown}
+		}
+	}
+}
+
+// allow returns ErrCircuitOpen while the circuit is open. After the cooldown the circuit is
+// half-open: a single probe request is let through and the rest are refused until
+// record reports its outcome.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if time.Now().Before(b.openUntil) {
+		return ErrCircuitOpen
+	}
+	if b.failures >= b.threshold {
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		log.Printf("🟡 Reflexion circuit breaker half-open: probing the service")
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a request
+func (b *circuitBreaker) record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.probing = false
+	if !failed {
+		if b.failures >= b.threshold {
+			log.Printf("🟢 Reflexion circuit breaker closed: service is responding again")
+		}
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+		log.Printf("🔴 Reflexion circuit breaker open after %d consecutive failures, skipping the service for %v", b.failures, b.cooldown)
+	}
+}
+
+// open reports whether requests are currently being refused
+func (b *circuitBreaker) open() bool {
+	if b == nil {
+		return false
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return time.Now().Before(b.openUntil) || b.probing
+}
+
+// CircuitOpen reports whether the circuit breaker is currently refusing requests
+func (c *Client) CircuitOpen() bool {
+	return c.breaker.open()
+}
@@ -0,0 +1,66 @@
+package reflexion
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// trip opens the breaker and waits out its cooldown
+func trip(t *testing.T, b *circuitBreaker) {
+	t.Helper()
+	for i := 0; i < b.threshold; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("request %d refused before the threshold: %v", i, err)
+		}
+		b.record(true)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen after %d failures, got %v", b.threshold, err)
+	}
+	time.Sleep(b.cooldown + 5*time.Millisecond)
+}
+
+func TestCircuitBreakerHalfOpenAllowsOneProbe(t *testing.T) {
+	b := &circuitBreaker{threshold: 2, cooldown: 20 * time.Millisecond}
+	trip(t, b)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("the first request after the cooldown should be let through, got %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("a second request while the probe is in flight should be refused, got %v", err)
+	}
+	if !b.open() {
+		t.Error("the breaker should report open while the probe is in flight")
+	}
+
+	b.record(false)
+	if b.open() {
+		t.Error("a successful probe should close the breaker")
+	}
+	for i := 0; i < 3; i++ {
+		if err := b.allow(); err != nil {
+			t.Errorf("request %d after closing refused: %v", i, err)
+		}
+	}
+}
+
+func TestCircuitBreakerFailedProbeReopens(t *testing.T) {
+	b := &circuitBreaker{threshold: 2, cooldown: 20 * time.Millisecond}
+	trip(t, b)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe refused: %v", err)
+	}
+	b.record(true)
+
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("a failed probe should open the breaker again, got %v", err)
+	}
+
+	time.Sleep(b.cooldown + 5*time.Millisecond)
+	if err := b.allow(); err != nil {
+		t.Errorf("a new probe should be let through after the next cooldown, got %v", err)
+	}
+}
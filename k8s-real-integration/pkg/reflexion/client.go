@@ -1,10 +1,10 @@
 package reflexion
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
@@ -12,26 +12,32 @@ import (
 
 // Client handles communication with the Python reflexion service
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL     string
+	httpClient  *http.Client
+	compress    atomic.Bool
+	maxAttempts int
+	baseDelay   time.Duration
+	breaker     *circuitBreaker
 }
 
-// NewClient creates a new reflexion client
+// NewClient creates a new reflexion client with the default retry and timeout settings
 func NewClient(baseURL string) *Client {
-	return &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 120 * time.Second, // 120 seconds timeout for AI processing
-		},
-	}
+	return NewClientWithOptions(baseURL)
+}
+
+// BaseURL returns the reflexion service URL the client talks to
+func (c *Client) BaseURL() string {
+	return c.baseURL
 }
 
 // RealK8sData represents the real Kubernetes data to send
 type RealK8sData struct {
-	PodSpec           *v1.Pod              `json:"pod_spec"`
-	Events            []v1.Event           `json:"events"`
-	Logs              []string             `json:"logs"`
-	ContainerStatuses []v1.ContainerStatus `json:"container_statuses,omitempty"`
+	PodSpec              *v1.Pod               `json:"pod_spec"`
+	Events               []v1.Event            `json:"events"`
+	Logs                 []string              `json:"logs"`
+	ContainerStatuses    []v1.ContainerStatus  `json:"container_statuses,omitempty"`
+	ContainerDiagnostics []ContainerDiagnostic `json:"container_diagnostics,omitempty"`
+	RecentChanges        []string              `json:"recent_changes,omitempty"`
 }
 
 // GoServiceErrorRequest is the request to send to Python reflexion service
@@ -56,17 +62,19 @@ type ReflexionResponse struct {
 type ProcessPodErrorResponse = ReflexionResponse
 
 // ProcessPodError sends a pod error to the reflexion service
-func (c *Client) ProcessPodError(pod *v1.Pod, events []v1.Event, logs []string, errorType string) (*ReflexionResponse, error) {
+func (c *Client) ProcessPodError(pod *v1.Pod, events []v1.Event, logs []string, errorType string, recentChanges []string) (*ReflexionResponse, error) {
 	// Prepare the request
 	request := GoServiceErrorRequest{
 		PodName:   pod.Name,
 		Namespace: pod.Namespace,
 		ErrorType: errorType,
 		RealK8sData: RealK8sData{
-			PodSpec:           pod,
-			Events:            events,
-			Logs:              logs,
-			ContainerStatuses: pod.Status.ContainerStatuses,
+			PodSpec:              pod,
+			Events:               events,
+			Logs:                 logs,
+			ContainerStatuses:    pod.Status.ContainerStatuses,
+			ContainerDiagnostics: BuildContainerDiagnostics(pod),
+			RecentChanges:        recentChanges,
 		},
 	}
 
@@ -76,9 +84,15 @@ func (c *Client) ProcessPodError(pod *v1.Pod, events []v1.Event, logs []string,
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	// Send request
+	// Send request, unless the service has been failing
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
 	url := c.baseURL + "/api/v1/reflexion/process-with-k8s-data"
-	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := c.withRetry("request", func() (*http.Response, error) {
+		return c.postJSON(url, jsonData)
+	})
+	c.breaker.record(serviceFailed(resp, err))
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request to %s: %w", url, err)
 	}
@@ -101,7 +115,9 @@ func (c *Client) ProcessPodError(pod *v1.Pod, events []v1.Event, logs []string,
 // HealthCheck checks if the reflexion service is healthy
 func (c *Client) HealthCheck() error {
 	url := c.baseURL + "/health"
-	resp, err := c.httpClient.Get(url)
+	resp, err := c.withRetry("health check", func() (*http.Response, error) {
+		return c.httpClient.Get(url)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to connect to reflexion service: %w", err)
 	}
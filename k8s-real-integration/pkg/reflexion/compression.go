@@ -0,0 +1,63 @@
+package reflexion
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// SetCompression enables gzip compression of request bodies sent to the reflexion service
+func (c *Client) SetCompression(enabled bool) {
+	c.compress.Store(enabled)
+}
+
+// gzipBody compresses a request body
+func gzipBody(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// postJSON posts a JSON body, gzip-compressed when enabled. If the service answers 415
+// Unsupported Media Type, compression is disabled and the request is resent uncompressed;
+// other errors are returned as they are, since they say nothing about the encoding.
+func (c *Client) postJSON(url string, jsonData []byte) (*http.Response, error) {
+	if !c.compress.Load() {
+		return c.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	}
+
+	compressed, err := gzipBody(jsonData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnsupportedMediaType {
+		// The service does not accept gzip bodies - fall back to plain JSON from now on
+		resp.Body.Close()
+		log.Printf("⚠️  Reflexion service rejected gzip body (status %d), falling back to uncompressed requests", resp.StatusCode)
+		c.compress.Store(false)
+		return c.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	}
+
+	return resp, nil
+}
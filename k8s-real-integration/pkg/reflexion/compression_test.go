@@ -0,0 +1,39 @@
+package reflexion
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCompressionFallsBackOnlyOnUnsupportedMediaType(t *testing.T) {
+	tests := []struct {
+		status       int
+		calls        int32
+		stillEnabled bool
+	}{
+		{http.StatusUnsupportedMediaType, 2, false},
+		{http.StatusBadRequest, 1, true},
+		{http.StatusUnprocessableEntity, 1, true},
+	}
+
+	for _, tt := range tests {
+		ts, calls := countingServer(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Content-Encoding") == "gzip" {
+				w.WriteHeader(tt.status)
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+		})
+		client := NewClientWithOptions(ts.URL, WithMaxAttempts(1), WithBaseDelay(time.Millisecond))
+		client.SetCompression(true)
+
+		client.ProcessPodError(testPod, nil, nil, "CrashLoopBackOff", nil)
+		if got := calls.Load(); got != tt.calls {
+			t.Errorf("status %d: %d requests, want %d", tt.status, got, tt.calls)
+		}
+		if got := client.compress.Load(); got != tt.stillEnabled {
+			t.Errorf("status %d: compression enabled = %v, want %v", tt.status, got, tt.stillEnabled)
+		}
+	}
+}
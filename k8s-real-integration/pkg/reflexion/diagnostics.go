@@ -0,0 +1,100 @@
+package reflexion
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ContainerDiagnostic is a pre-digested, human-readable summary of a container's status
+type ContainerDiagnostic struct {
+	Name                   string `json:"name"`
+	Image                  string `json:"image"`
+	State                  string `json:"state"`
+	RestartCount           int32  `json:"restart_count"`
+	WaitingReason          string `json:"waiting_reason,omitempty"`
+	InBackOff              bool   `json:"in_back_off"`
+	ExitCode               *int32 `json:"exit_code,omitempty"`
+	ExitInterpretation     string `json:"exit_interpretation,omitempty"`
+	OOMKilled              bool   `json:"oom_killed"`
+	LastTerminationReason  string `json:"last_termination_reason,omitempty"`
+	LastTerminationMessage string `json:"last_termination_message,omitempty"`
+	LastExitCode           *int32 `json:"last_exit_code,omitempty"`
+	LastExitInterpretation string `json:"last_exit_interpretation,omitempty"`
+}
+
+// InterpretExitCode returns a human-readable explanation of a container exit code
+func InterpretExitCode(exitCode int32) string {
+	switch exitCode {
+	case 0:
+		return "completed successfully"
+	case 1:
+		return "general application error"
+	case 2:
+		return "misuse of shell builtin or invalid arguments"
+	case 126:
+		return "command found but not executable (permission problem)"
+	case 127:
+		return "command not found in image"
+	case 128:
+		return "container runtime failed to start the process"
+	case 137:
+		return "SIGKILL (signal 9) - killed by the OOM killer or forcibly terminated"
+	case 139:
+		return "SIGSEGV (signal 11) - segmentation fault"
+	case 143:
+		return "SIGTERM (signal 15) - termination requested, often by a failing liveness probe"
+	}
+	if exitCode > 128 && exitCode < 160 {
+		return fmt.Sprintf("killed by signal %d", exitCode-128)
+	}
+	return "application-specific error"
+}
+
+// BuildContainerDiagnostics summarizes container statuses for the reflexion service
+func BuildContainerDiagnostics(pod *v1.Pod) []ContainerDiagnostic {
+	statuses := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+	diagnostics := make([]ContainerDiagnostic, 0, len(statuses))
+	for _, status := range statuses {
+		diagnostic := ContainerDiagnostic{
+			Name:         status.Name,
+			Image:        status.Image,
+			RestartCount: status.RestartCount,
+		}
+
+		switch {
+		case status.State.Waiting != nil:
+			diagnostic.State = "waiting"
+			diagnostic.WaitingReason = status.State.Waiting.Reason
+			diagnostic.InBackOff = strings.HasSuffix(status.State.Waiting.Reason, "BackOff")
+		case status.State.Running != nil:
+			diagnostic.State = "running"
+		case status.State.Terminated != nil:
+			diagnostic.State = "terminated"
+			exitCode := status.State.Terminated.ExitCode
+			diagnostic.ExitCode = &exitCode
+			diagnostic.ExitInterpretation = InterpretExitCode(exitCode)
+			diagnostic.OOMKilled = status.State.Terminated.Reason == "OOMKilled"
+		default:
+			diagnostic.State = "unknown"
+		}
+
+		if last := status.LastTerminationState.Terminated; last != nil {
+			exitCode := last.ExitCode
+			diagnostic.LastTerminationReason = last.Reason
+			diagnostic.LastTerminationMessage = last.Message
+			diagnostic.LastExitCode = &exitCode
+			diagnostic.LastExitInterpretation = InterpretExitCode(exitCode)
+			if last.Reason == "OOMKilled" {
+				diagnostic.OOMKilled = true
+			}
+		}
+
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	return diagnostics
+}
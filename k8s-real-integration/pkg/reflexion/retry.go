@@ -0,0 +1,111 @@
+package reflexion
+
+import (
+	"errors"
+	"log"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 1 * time.Second
+	defaultTimeout     = 120 * time.Second // AI processing can take a while
+)
+
+// Option configures a Client created with NewClientWithOptions
+type Option func(*Client)
+
+// WithMaxAttempts sets how many times a request is tried before giving up (1 disables retries)
+func WithMaxAttempts(attempts int) Option {
+	return func(c *Client) {
+		if attempts > 0 {
+			c.maxAttempts = attempts
+		}
+	}
+}
+
+// WithBaseDelay sets the delay before the first retry; later retries double it
+func WithBaseDelay(delay time.Duration) Option {
+	return func(c *Client) {
+		if delay > 0 {
+			c.baseDelay = delay
+		}
+	}
+}
+
+// WithTimeout sets the timeout of a single request attempt
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		if timeout > 0 {
+			c.httpClient.Timeout = timeout
+		}
+	}
+}
+
+// NewClientWithOptions creates a reflexion client with tuned retry and timeout settings
+func NewClientWithOptions(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:     baseURL,
+		httpClient:  &http.Client{Timeout: defaultTimeout},
+		maxAttempts: defaultMaxAttempts,
+		baseDelay:   defaultBaseDelay,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// retryable reports whether a failed attempt is safe and worth repeating. Processing a pod
+// applies fixes and is not idempotent, so only failures where the service cannot have
+// started working are retried: the connection could not be opened, or a proxy in front
+// of the service answered 502/503/504. Client timeouts and plain 500s are not retried;
+// the first run may still be applying changes.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial"
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// serviceFailed reports whether an attempt shows the service is unhealthy, which is what
+// the circuit breaker counts
+func serviceFailed(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// backoff returns the jittered delay before the given retry (1-based)
+func (c *Client) backoff(retry int) time.Duration {
+	delay := c.baseDelay << (retry - 1)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// withRetry runs send until it succeeds, fails with a non-retryable response, or runs out of attempts
+func (c *Client) withRetry(what string, send func() (*http.Response, error)) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := send()
+		if !retryable(resp, err) || attempt >= c.maxAttempts {
+			return resp, err
+		}
+
+		var reason string
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			resp.Body.Close()
+		}
+
+		delay := c.backoff(attempt)
+		log.Printf("🔁 Reflexion %s failed (attempt %d/%d: %s), retrying in %v", what, attempt, c.maxAttempts, reason, delay.Round(time.Millisecond))
+		time.Sleep(delay)
+	}
+}
@@ -0,0 +1,83 @@
+package reflexion
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testPod = &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "app"}}
+
+// countingServer answers every request with handler and counts the requests
+func countingServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		handler(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &calls
+}
+
+func TestProcessPodErrorRetriesOnlyUnstartedRequests(t *testing.T) {
+	tests := []struct {
+		status int
+		calls  int32
+	}{
+		{http.StatusBadGateway, 3},
+		{http.StatusServiceUnavailable, 3},
+		{http.StatusGatewayTimeout, 3},
+		{http.StatusInternalServerError, 1},
+		{http.StatusBadRequest, 1},
+	}
+
+	for _, tt := range tests {
+		ts, calls := countingServer(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+		})
+		client := NewClientWithOptions(ts.URL, WithMaxAttempts(3), WithBaseDelay(time.Millisecond))
+
+		if _, err := client.ProcessPodError(testPod, nil, nil, "CrashLoopBackOff", nil); err == nil {
+			t.Errorf("status %d: expected an error", tt.status)
+		}
+		if got := calls.Load(); got != tt.calls {
+			t.Errorf("status %d: %d requests, want %d", tt.status, got, tt.calls)
+		}
+	}
+}
+
+func TestProcessPodErrorDoesNotRetryClientTimeout(t *testing.T) {
+	ts, calls := countingServer(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	})
+	client := NewClientWithOptions(ts.URL, WithMaxAttempts(3), WithBaseDelay(time.Millisecond),
+		WithTimeout(50*time.Millisecond))
+
+	if _, err := client.ProcessPodError(testPod, nil, nil, "CrashLoopBackOff", nil); err == nil {
+		t.Fatal("expected a timeout error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("%d requests after a client timeout, want 1", got)
+	}
+}
+
+func TestProcessPodErrorRetriesRefusedConnection(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	url := ts.URL
+	ts.Close()
+
+	client := NewClientWithOptions(url, WithMaxAttempts(2), WithBaseDelay(time.Millisecond))
+	_, err := client.ProcessPodError(testPod, nil, nil, "CrashLoopBackOff", nil)
+	if err == nil {
+		t.Fatal("expected a connection error")
+	}
+	if !retryable(nil, err) {
+		t.Errorf("a refused connection should be retryable: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"k8s-real-integration-go/pkg/executor"
+)
+
+// Command categories sent by the reflexion service
+const (
+	categoryBackup     = "backup_commands"
+	categoryFix        = "fix_commands"
+	categoryValidation = "validation_commands"
+	categoryRollback   = "rollback_commands"
+)
+
+// defaultExecutionOrder is used when a request does not specify its own order
+var defaultExecutionOrder = []string{categoryBackup, categoryFix, categoryValidation}
+
+// validateExecutionOrder checks a requested execution order against the supplied commands.
+// Rollback commands are never part of the normal order, backup must always run before fix,
+// and every phase that has commands must be listed unless it is named in skip, so none is
+// skipped silently.
+func validateExecutionOrder(order, skip []string, commands map[string][]string) error {
+	seen := make(map[string]int)
+	for i, category := range order {
+		if err := checkOrderCategory(category, "execution_order"); err != nil {
+			return err
+		}
+		if _, dup := seen[category]; dup {
+			return fmt.Errorf("command category %q listed more than once in execution_order", category)
+		}
+		seen[category] = i
+	}
+
+	skipped := make(map[string]bool)
+	for _, category := range skip {
+		if err := checkOrderCategory(category, "skip_categories"); err != nil {
+			return err
+		}
+		if _, listed := seen[category]; listed {
+			return fmt.Errorf("command category %q is both in execution_order and skip_categories", category)
+		}
+		skipped[category] = true
+	}
+
+	for _, category := range defaultExecutionOrder {
+		if _, listed := seen[category]; !listed && !skipped[category] && len(commands[category]) > 0 {
+			return fmt.Errorf("execution_order omits %s, but commands for it were supplied; list it in skip_categories to leave it out", category)
+		}
+	}
+
+	backupIdx, hasBackup := seen[categoryBackup]
+	fixIdx, hasFix := seen[categoryFix]
+	if hasBackup && hasFix && backupIdx > fixIdx {
+		return fmt.Errorf("%s must run before %s", categoryBackup, categoryFix)
+	}
+
+	return nil
+}
+
+// checkOrderCategory reports whether category may appear in the given request field
+func checkOrderCategory(category, field string) error {
+	switch category {
+	case categoryBackup, categoryFix, categoryValidation:
+		return nil
+	case categoryRollback:
+		return fmt.Errorf("%s cannot be part of %s; set rollback_on_failure instead", categoryRollback, field)
+	default:
+		return fmt.Errorf("unknown command category %q in %s", category, field)
+	}
+}
+
+// combinedStatus derives the status of a multi-category run. The executor judges each
+// category by the pod's state once its commands ran, so the last category speaks for the
+// pod after the whole run; earlier categories, such as a backup taken while the pod was
+// still failing, only count through their failed commands, which cap the result at partial.
+func combinedStatus(reports []*executor.ExecutionReport) string {
+	if len(reports) == 0 {
+		return "success"
+	}
+	status := reports[len(reports)-1].Status
+	for _, report := range reports {
+		if report.FailureCount > 0 && status == "success" {
+			status = "partial"
+		}
+	}
+	return status
+}
+
+// renderCategories renders the command templates of every category in the request
+func renderCategories(req ExecuteCommandsRequest) map[string][]string {
+	rendered := make(map[string][]string, len(req.Commands))
+	for category, commands := range req.Commands {
+		rendered[category] = renderCommands(commands, req)
+	}
+	return rendered
+}
+
+// executeCategories runs the given command categories in order and merges their reports.
+// It also reports whether any fix command failed.
+func (s *HTTPServer) executeCategories(ctx context.Context, req ExecuteCommandsRequest, commands map[string][]string, order []string) (*executor.ExecutionReport, bool, error) {
+	startTime := time.Now()
+	combined := &executor.ExecutionReport{
+		PodName:   req.PodName,
+		Namespace: req.Namespace,
+		ErrorType: req.ErrorType,
+		Commands:  []executor.CommandResult{},
+		Status:    "success",
+	}
+
+	commandTimeout := time.Duration(req.CommandTimeout) * time.Second
+	fixFailed := false
+	var reports []*executor.ExecutionReport
+
+	for _, category := range order {
+		categoryCommands := commands[category]
+		if len(categoryCommands) == 0 {
+			continue
+		}
+		log.Printf("📂 Category: %s - %d commands", category, len(categoryCommands))
+
+		report, err := s.executor.ExecuteCommandsWithTimeout(ctx, categoryCommands, commandTimeout, req.PodName, req.Namespace, req.ErrorType)
+		if err != nil {
+			return nil, fixFailed, fmt.Errorf("%s: %w", category, err)
+		}
+
+		combined.TotalCommands += report.TotalCommands
+		combined.SuccessCount += report.SuccessCount
+		combined.FailureCount += report.FailureCount
+		combined.Commands = append(combined.Commands, report.Commands...)
+		reports = append(reports, report)
+
+		if category == categoryFix && report.FailureCount > 0 {
+			fixFailed = true
+		}
+	}
+
+	combined.Status = combinedStatus(reports)
+	combined.Duration = time.Since(startTime).String()
+	return combined, fixFailed, nil
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"testing"
+
+	"k8s-real-integration-go/pkg/executor"
+)
+
+func TestValidateExecutionOrder(t *testing.T) {
+	all := map[string][]string{
+		categoryBackup:     {"kubectl get pod web -o yaml"},
+		categoryFix:        {"kubectl delete pod web"},
+		categoryValidation: {"kubectl get pod web"},
+	}
+	fixOnly := map[string][]string{categoryFix: {"kubectl delete pod web"}}
+
+	tests := []struct {
+		name     string
+		order    []string
+		skip     []string
+		commands map[string][]string
+		valid    bool
+	}{
+		{"default order", defaultExecutionOrder, nil, all, true},
+		{"fix only with fix commands", []string{categoryFix}, nil, fixOnly, true},
+		{"validation before fix", []string{categoryBackup, categoryValidation, categoryFix}, nil, all, true},
+		{"backup after fix", []string{categoryFix, categoryBackup, categoryValidation}, nil, all, false},
+		{"omits supplied backup", []string{categoryFix, categoryValidation}, nil, all, false},
+		{"omits supplied validation", []string{categoryBackup, categoryFix}, nil, all, false},
+		{"skips supplied validation", []string{categoryBackup, categoryFix}, []string{categoryValidation}, all, true},
+		{"skips a listed category", []string{categoryBackup, categoryFix}, []string{categoryFix}, all, false},
+		{"skips rollback", []string{categoryFix}, []string{categoryRollback}, fixOnly, false},
+		{"rollback listed", []string{categoryFix, categoryRollback}, nil, fixOnly, false},
+		{"unknown category", []string{"cleanup_commands"}, nil, fixOnly, false},
+		{"duplicate category", []string{categoryFix, categoryFix}, nil, fixOnly, false},
+	}
+
+	for _, tt := range tests {
+		err := validateExecutionOrder(tt.order, tt.skip, tt.commands)
+		if tt.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestCombinedStatus(t *testing.T) {
+	report := func(status string, failures int) *executor.ExecutionReport {
+		return &executor.ExecutionReport{Status: status, FailureCount: failures}
+	}
+
+	tests := []struct {
+		name    string
+		reports []*executor.ExecutionReport
+		want    string
+	}{
+		{"all succeeded", []*executor.ExecutionReport{report("failed", 0), report("success", 0), report("success", 0)}, "success"},
+		{"fix command failed", []*executor.ExecutionReport{report("failed", 0), report("partial", 1), report("success", 0)}, "partial"},
+		{"backup command failed", []*executor.ExecutionReport{report("failed", 1), report("success", 0)}, "partial"},
+		{"pod still failing", []*executor.ExecutionReport{report("success", 0), report("failed", 1)}, "failed"},
+		{"nothing ran", nil, "success"},
+	}
+
+	for _, tt := range tests {
+		if got := combinedStatus(tt.reports); got != tt.want {
+			t.Errorf("%s: combinedStatus = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
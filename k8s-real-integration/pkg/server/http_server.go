@@ -3,18 +3,33 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"k8s-real-integration-go/pkg/audit"
 	"k8s-real-integration-go/pkg/executor"
 )
 
+// ErrUnknownCluster is returned by an analyzer asked about a cluster it does not watch
+var ErrUnknownCluster = errors.New("unknown cluster")
+
 // HTTPServer handles HTTP requests for kubectl command execution
 type HTTPServer struct {
-	port     int
-	executor *executor.KubectlExecutor
+	port           int
+	srv            *http.Server
+	executor       *executor.KubectlExecutor
+	commandTimeout time.Duration
+	recentFixes    func() interface{}
+	readinessCheck func() error
+	setPaused      func(bool)
+	isPaused       func() bool
+	analyzer       func(ctx context.Context, cluster, namespace, name string) (interface{}, error)
+	audit          *audit.AuditLogger
 }
 
 // ExecuteCommandsRequest represents the request for executing kubectl commands
@@ -25,6 +40,15 @@ type ExecuteCommandsRequest struct {
 	Commands  map[string][]string `json:"commands"`
 	DryRun    bool                `json:"dry_run"`
 	Timeout   int                 `json:"timeout"` // seconds
+	// CommandTimeout bounds each individual command (seconds); defaults to the server's
+	// --command-timeout, capped at Timeout
+	CommandTimeout int `json:"command_timeout,omitempty"`
+	// ExecutionOrder lists the command categories to run, in order; backup must precede fix
+	ExecutionOrder []string `json:"execution_order,omitempty"`
+	// SkipCategories lists command categories to leave out of ExecutionOrder on purpose
+	SkipCategories []string `json:"skip_categories,omitempty"`
+	// RollbackOnFailure runs rollback_commands when any fix command fails
+	RollbackOnFailure bool `json:"rollback_on_failure,omitempty"`
 }
 
 // ExecuteCommandsResponse represents the response after executing kubectl commands
@@ -39,36 +63,87 @@ type ExecuteCommandsResponse struct {
 	Status        string                    `json:"status"`
 	Report        *executor.ExecutionReport `json:"report"`
 	Commands      []executor.CommandResult  `json:"commands"`
+	RolledBack    bool                      `json:"rolled_back"`
 	Message       string                    `json:"message"`
 }
 
 // NewHTTPServer creates a new HTTP server for kubectl command execution
 func NewHTTPServer(port int, dryRun bool, timeout time.Duration) *HTTPServer {
 	return &HTTPServer{
-		port:     port,
-		executor: executor.NewKubectlExecutor(dryRun, timeout),
+		port:           port,
+		srv:            &http.Server{Addr: fmt.Sprintf(":%d", port)},
+		executor:       executor.NewKubectlExecutor(dryRun, timeout),
+		commandTimeout: timeout,
 	}
 }
 
+// SetRecentFixesProvider sets the source of records served by /api/v1/recent-fixes
+func (s *HTTPServer) SetRecentFixesProvider(provider func() interface{}) {
+	s.recentFixes = provider
+}
+
+// SetReadinessCheck sets the check behind /readyz; a non-nil error reports not ready
+func (s *HTTPServer) SetReadinessCheck(check func() error) {
+	s.readinessCheck = check
+}
+
+// SetPauseControl wires /api/v1/pause and /api/v1/resume to the pod watchers
+func (s *HTTPServer) SetPauseControl(setPaused func(bool), isPaused func() bool) {
+	s.setPaused = setPaused
+	s.isPaused = isPaused
+}
+
+// SetAnalyzer sets the diagnosis behind /api/v1/analyze
+func (s *HTTPServer) SetAnalyzer(analyzer func(ctx context.Context, cluster, namespace, name string) (interface{}, error)) {
+	s.analyzer = analyzer
+}
+
+// SetAuditLogger sets a logger that records every executed command batch persistently
+func (s *HTTPServer) SetAuditLogger(a *audit.AuditLogger) {
+	s.audit = a
+}
+
+// paused reports whether fixes are currently paused
+func (s *HTTPServer) paused() bool {
+	return s.isPaused != nil && s.isPaused()
+}
+
 // Start starts the HTTP server
 func (s *HTTPServer) Start() error {
 	// Validate kubectl availability
 	if !s.executor.IsKubectlAvailable() {
-		return fmt.Errorf("kubectl is not available in system PATH")
+		return fmt.Errorf("%w in system PATH", executor.ErrKubectlNotFound)
 	}
 
 	// Validate Kubernetes connection
 	if err := s.executor.ValidateKubernetesConnection(); err != nil {
-		return fmt.Errorf("kubernetes connection validation failed: %v", err)
+		return fmt.Errorf("kubernetes connection validation failed: %w", err)
 	}
 
-	// Setup HTTP routes
-	http.HandleFunc("/api/v1/execute-commands", s.handleExecuteCommands)
-	http.HandleFunc("/api/v1/health", s.handleHealth)
-	http.HandleFunc("/api/v1/kubectl-status", s.handleKubectlStatus)
+	// Setup HTTP routes on a private mux so several servers can coexist
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/execute-commands", s.handleExecuteCommands)
+	mux.HandleFunc("/api/v1/rollback", s.handleRollback)
+	mux.HandleFunc("/api/v1/health", s.handleHealth)
+	mux.HandleFunc("/api/v1/kubectl-status", s.handleKubectlStatus)
+	mux.HandleFunc("/api/v1/recent-fixes", s.handleRecentFixes)
+	mux.HandleFunc("/api/v1/pause", s.handlePause(true))
+	mux.HandleFunc("/api/v1/resume", s.handlePause(false))
+	mux.HandleFunc("/api/v1/analyze", s.handleAnalyze)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	s.srv.Handler = mux
 
 	log.Printf("🚀 Starting HTTP server on port %d", s.port)
-	return http.ListenAndServe(fmt.Sprintf(":%d", s.port), nil)
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting requests and waits for in-flight ones (such as running kubectl
+// commands) to finish until ctx expires
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
 }
 
 // handleExecuteCommands handles kubectl command execution requests
@@ -78,6 +153,11 @@ func (s *HTTPServer) handleExecuteCommands(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if s.paused() {
+		http.Error(w, "Fixes are paused; POST /api/v1/resume to re-enable", http.StatusServiceUnavailable)
+		return
+	}
+
 	log.Printf("📋 Received kubectl command execution request")
 
 	// Parse request
@@ -94,52 +174,76 @@ func (s *HTTPServer) handleExecuteCommands(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Set defaults
-	if req.Namespace == "" {
-		req.Namespace = "default"
-	}
-	if req.Timeout == 0 {
-		req.Timeout = 60 // 60 seconds default
+	// Set defaults and validate fields used for command templating
+	applyRequestDefaults(&req, s.commandTimeout)
+	if err := validateTemplateFields(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
 	log.Printf("🔧 Executing kubectl commands for pod: %s (error: %s, dry-run: %v)",
 		req.PodName, req.ErrorType, req.DryRun)
 
-	// Execute commands in correct order: backup -> fix -> validation (skip rollback)
-	var allCommands []string
-	executionOrder := []string{"backup_commands", "fix_commands", "validation_commands"}
-
-	for _, category := range executionOrder {
-		if commands, exists := req.Commands[category]; exists {
-			log.Printf("📂 Category: %s - %d commands", category, len(commands))
-			allCommands = append(allCommands, commands...)
-		}
+	// Resolve execution order: backup -> fix -> validation unless the request overrides it
+	executionOrder := req.ExecutionOrder
+	if len(executionOrder) == 0 {
+		executionOrder = defaultExecutionOrder
 	}
+	if err := validateExecutionOrder(executionOrder, req.SkipCategories, req.Commands); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Substitute {{.PodName}}/{{.Namespace}} placeholders from the validated request
+	commands := renderCategories(req)
 
 	// Execute commands with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(req.Timeout)*time.Second)
+	ctx, cancel := requestContext(req)
 	defer cancel()
 
-	report, err := s.executor.ExecuteCommands(ctx, allCommands, req.PodName, req.Namespace, req.ErrorType)
+	report, fixFailed, err := s.executeCategories(ctx, req, commands, executionOrder)
 	if err != nil {
 		log.Printf("❌ Command execution failed: %v", err)
 		http.Error(w, fmt.Sprintf("Command execution failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 
+	// Roll back when the fix failed and the caller asked for it
+	rolledBack := false
+	if fixFailed && req.RollbackOnFailure && len(commands[categoryRollback]) > 0 {
+		log.Printf("↩️  Fix commands failed, executing rollback commands for pod %s", req.PodName)
+		// Rollback gets its own time budget: the fix may have used up the batch's
+		rollbackCtx, rollbackCancel := requestContext(req)
+		defer rollbackCancel()
+		rollbackReport, _, err := s.executeCategories(rollbackCtx, req, commands, []string{categoryRollback})
+		if err != nil {
+			log.Printf("❌ Rollback execution failed: %v", err)
+		} else {
+			report.TotalCommands += rollbackReport.TotalCommands
+			report.SuccessCount += rollbackReport.SuccessCount
+			report.FailureCount += rollbackReport.FailureCount
+			report.Commands = append(report.Commands, rollbackReport.Commands...)
+			report.Status = "rolled_back"
+			rolledBack = true
+		}
+	}
+
+	s.auditExecution(ctx, req, report)
+
 	// Prepare response
 	response := ExecuteCommandsResponse{
 		PodName:       req.PodName,
 		Namespace:     req.Namespace,
 		ErrorType:     req.ErrorType,
-		TotalCommands: len(allCommands),
+		TotalCommands: report.TotalCommands,
 		SuccessCount:  report.SuccessCount,
 		FailureCount:  report.FailureCount,
 		Duration:      report.Duration,
 		Status:        report.Status,
 		Report:        report,
 		Commands:      report.Commands,
-		Message:       fmt.Sprintf("Executed %d commands for %s: %s", len(allCommands), req.ErrorType, report.Status),
+		RolledBack:    rolledBack,
+		Message:       fmt.Sprintf("Executed %d commands for %s: %s", report.TotalCommands, req.ErrorType, report.Status),
 	}
 
 	// Set response headers
@@ -155,6 +259,88 @@ func (s *HTTPServer) handleExecuteCommands(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// applyRequestDefaults fills in the namespace and timeouts a request left unset
+func applyRequestDefaults(req *ExecuteCommandsRequest, commandTimeout time.Duration) {
+	if req.Namespace == "" {
+		req.Namespace = "default"
+	}
+	if req.Timeout == 0 {
+		req.Timeout = 60 // 60 seconds default
+	}
+	if req.CommandTimeout <= 0 {
+		// A single command may use the server's per-command timeout, but never more than the batch
+		req.CommandTimeout = int(commandTimeout / time.Second)
+		if req.CommandTimeout <= 0 || req.CommandTimeout > req.Timeout {
+			req.CommandTimeout = req.Timeout
+		}
+	}
+}
+
+// requestContext returns a context bounded by the request's timeout. A dry-run request never
+// changes the cluster, even on a live server.
+func requestContext(req ExecuteCommandsRequest) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(req.Timeout)*time.Second)
+	if req.DryRun {
+		ctx = executor.WithDryRun(ctx)
+	}
+	return ctx, cancel
+}
+
+// handleRollback runs only the rollback_commands of a request, so a caller can undo a bad fix.
+// Rollbacks are allowed while fixes are paused, since pausing is often the first step of undoing one.
+func (s *HTTPServer) handleRollback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	log.Printf("📋 Received rollback request")
+
+	var req ExecuteCommandsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Printf("❌ Failed to parse request: %v", err)
+		http.Error(w, "Invalid request format", http.StatusBadRequest)
+		return
+	}
+	if req.PodName == "" || req.ErrorType == "" {
+		http.Error(w, "Missing required fields: pod_name, error_type", http.StatusBadRequest)
+		return
+	}
+	if len(req.Commands[categoryRollback]) == 0 {
+		http.Error(w, fmt.Sprintf("Missing %s in commands", categoryRollback), http.StatusBadRequest)
+		return
+	}
+
+	applyRequestDefaults(&req, s.commandTimeout)
+	if err := validateTemplateFields(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	commands := renderCategories(req)
+
+	ctx, cancel := requestContext(req)
+	defer cancel()
+
+	log.Printf("↩️  Executing rollback commands for pod: %s (error: %s, dry-run: %v)", req.PodName, req.ErrorType, req.DryRun)
+	report, _, err := s.executeCategories(ctx, req, commands, []string{categoryRollback})
+	if err != nil {
+		log.Printf("❌ Rollback execution failed: %v", err)
+		http.Error(w, fmt.Sprintf("Rollback execution failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.auditExecution(ctx, req, report)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Printf("❌ Failed to encode response: %v", err)
+	} else {
+		log.Printf("✅ Rollback completed: %s (%d/%d succeeded)", report.Status, report.SuccessCount, report.TotalCommands)
+	}
+}
+
 // handleHealth handles health check requests
 func (s *HTTPServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 	// Add CORS headers
@@ -173,6 +359,7 @@ func (s *HTTPServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 		"timestamp":         time.Now().Format(time.RFC3339),
 		"service":           "kubectl-executor",
 		"kubectl_available": s.executor.IsKubectlAvailable(),
+		"paused":            s.paused(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -197,3 +384,139 @@ func (s *HTTPServer) handleKubectlStatus(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(status)
 }
+
+// handleRecentFixes returns the most recent fix records kept by the pod watcher
+func (s *HTTPServer) handleRecentFixes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var fixes interface{} = []interface{}{}
+	if s.recentFixes != nil {
+		fixes = s.recentFixes()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"fixes":     fixes,
+		"timestamp": time.Now().Format(time.RFC3339),
+	})
+}
+
+// auditExecution appends an executed command batch to the audit log
+func (s *HTTPServer) auditExecution(ctx context.Context, req ExecuteCommandsRequest, report *executor.ExecutionReport) {
+	if s.audit == nil {
+		return
+	}
+
+	commands := make([]string, 0, len(report.Commands))
+	for _, result := range report.Commands {
+		commands = append(commands, result.Command)
+	}
+
+	err := s.audit.Log(audit.Entry{
+		Source:    audit.SourceExecutor,
+		Namespace: req.Namespace,
+		PodName:   req.PodName,
+		ErrorType: req.ErrorType,
+		Commands:  commands,
+		Success:   report.Status == "success",
+		DryRun:    s.executor.IsDryRun(ctx),
+		Message:   fmt.Sprintf("%d/%d commands succeeded, status %s", report.SuccessCount, report.TotalCommands, report.Status),
+	})
+	if err != nil {
+		log.Printf("⚠️  Failed to audit command execution for pod %s/%s: %v", req.Namespace, req.PodName, err)
+	}
+}
+
+// AnalyzeRequest asks for the diagnosis of a pod without fixing it
+type AnalyzeRequest struct {
+	PodName   string `json:"pod_name"`
+	Namespace string `json:"namespace"`
+	Cluster   string `json:"cluster,omitempty"` // multi-cluster runs only; defaults to the first cluster
+}
+
+// handleAnalyze diagnoses a pod without executing any fix
+func (s *HTTPServer) handleAnalyze(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.analyzer == nil {
+		http.Error(w, "Analysis is not available", http.StatusNotImplemented)
+		return
+	}
+
+	var req AnalyzeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.PodName == "" {
+		http.Error(w, "pod_name is required", http.StatusBadRequest)
+		return
+	}
+	if req.Namespace == "" {
+		req.Namespace = "default"
+	}
+
+	log.Printf("🔎 Analysis requested for pod %s/%s", req.Namespace, req.PodName)
+
+	analysis, err := s.analyzer(r.Context(), req.Cluster, req.Namespace, req.PodName)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if apierrors.IsNotFound(err) || errors.Is(err, ErrUnknownCluster) {
+			status = http.StatusNotFound
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(analysis)
+}
+
+// handleReadyz reports whether the pod watchers are making progress
+func (s *HTTPServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if s.readinessCheck != nil {
+		if err := s.readinessCheck(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if s.paused() {
+		w.Write([]byte("ok (paused)"))
+		return
+	}
+	w.Write([]byte("ok"))
+}
+
+// handlePause returns a handler that pauses or resumes fixes
+func (s *HTTPServer) handlePause(pause bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if s.setPaused == nil {
+			http.Error(w, "Pause control is not available", http.StatusNotImplemented)
+			return
+		}
+
+		s.setPaused(pause)
+		if pause {
+			log.Printf("⏸️  Fixes paused via HTTP")
+		} else {
+			log.Printf("▶️  Fixes resumed via HTTP")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"paused":    s.paused(),
+			"timestamp": time.Now().Format(time.RFC3339),
+		})
+	}
+}
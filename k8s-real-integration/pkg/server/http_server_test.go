@@ -0,0 +1,29 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyRequestDefaultsCommandTimeout(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            ExecuteCommandsRequest
+		serverTimeout  time.Duration
+		commandTimeout int
+	}{
+		{"server timeout within the batch", ExecuteCommandsRequest{Timeout: 120}, 30 * time.Second, 30},
+		{"server timeout longer than the batch", ExecuteCommandsRequest{Timeout: 20}, 30 * time.Second, 20},
+		{"default batch timeout", ExecuteCommandsRequest{}, 30 * time.Second, 30},
+		{"no server timeout", ExecuteCommandsRequest{Timeout: 40}, 0, 40},
+		{"explicit command timeout", ExecuteCommandsRequest{Timeout: 120, CommandTimeout: 90}, 30 * time.Second, 90},
+	}
+
+	for _, tt := range tests {
+		req := tt.req
+		applyRequestDefaults(&req, tt.serverTimeout)
+		if req.CommandTimeout != tt.commandTimeout {
+			t.Errorf("%s: CommandTimeout = %d, want %d", tt.name, req.CommandTimeout, tt.commandTimeout)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// errorTypePattern matches the error types the watcher reports, e.g. CrashLoopBackOff
+var errorTypePattern = regexp.MustCompile(`^[A-Za-z]*$`)
+
+// validateTemplateFields ensures request fields are safe to substitute into commands: pod
+// and namespace must be valid Kubernetes names and the error type a single word, so none
+// of them can add arguments or quotes to a command
+func validateTemplateFields(req ExecuteCommandsRequest) error {
+	if errs := validation.IsDNS1123Subdomain(req.PodName); len(errs) > 0 {
+		return fmt.Errorf("invalid pod_name %q: %s", req.PodName, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsDNS1123Label(req.Namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", req.Namespace, strings.Join(errs, "; "))
+	}
+	if !errorTypePattern.MatchString(req.ErrorType) {
+		return fmt.Errorf("invalid error_type %q: must contain only letters", req.ErrorType)
+	}
+	return nil
+}
+
+// renderCommands substitutes the {{.PodName}}, {{.Namespace}} and {{.ErrorType}} placeholders
+// in each command with the values from the validated request. Only these exact placeholders
+// are replaced, so kubectl's own templates such as -o go-template='{{.metadata.name}}' pass
+// through untouched.
+func renderCommands(commands []string, req ExecuteCommandsRequest) []string {
+	replacer := strings.NewReplacer(
+		"{{.PodName}}", req.PodName,
+		"{{.Namespace}}", req.Namespace,
+		"{{.ErrorType}}", req.ErrorType,
+	)
+
+	rendered := make([]string, 0, len(commands))
+	for _, command := range commands {
+		rendered = append(rendered, replacer.Replace(command))
+	}
+	return rendered
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenderCommands(t *testing.T) {
+	req := ExecuteCommandsRequest{PodName: "web-1", Namespace: "app", ErrorType: "CrashLoopBackOff"}
+
+	commands := []string{
+		"kubectl delete pod {{.PodName}} -n {{.Namespace}}",
+		"kubectl annotate pod {{.PodName}} -n {{.Namespace}} reason={{.ErrorType}}",
+		"kubectl get pods -n app",
+		"kubectl get pod {{.PodName}} -n {{.Namespace}} -o go-template='{{.metadata.name}} {{range .status.containerStatuses}}{{.restartCount}}{{end}}'",
+		"kubectl get pod web-1 -n app -o jsonpath='{.status.phase}'",
+		"kubectl get pod {{ .PodName }} -n app",
+	}
+	want := []string{
+		"kubectl delete pod web-1 -n app",
+		"kubectl annotate pod web-1 -n app reason=CrashLoopBackOff",
+		"kubectl get pods -n app",
+		"kubectl get pod web-1 -n app -o go-template='{{.metadata.name}} {{range .status.containerStatuses}}{{.restartCount}}{{end}}'",
+		"kubectl get pod web-1 -n app -o jsonpath='{.status.phase}'",
+		"kubectl get pod {{ .PodName }} -n app",
+	}
+
+	if got := renderCommands(commands, req); !reflect.DeepEqual(got, want) {
+		t.Errorf("renderCommands:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestValidateTemplateFields(t *testing.T) {
+	tests := []struct {
+		req   ExecuteCommandsRequest
+		valid bool
+	}{
+		{ExecuteCommandsRequest{PodName: "web-1", Namespace: "app", ErrorType: "CrashLoopBackOff"}, true},
+		{ExecuteCommandsRequest{PodName: "web-1", Namespace: "app"}, true},
+		{ExecuteCommandsRequest{PodName: "web 1", Namespace: "app", ErrorType: "OOMKilled"}, false},
+		{ExecuteCommandsRequest{PodName: "web-1", Namespace: "App", ErrorType: "OOMKilled"}, false},
+		{ExecuteCommandsRequest{PodName: "web-1", Namespace: "app", ErrorType: "OOM --all"}, false},
+		{ExecuteCommandsRequest{PodName: "web-1", Namespace: "app", ErrorType: `OOM"Killed`}, false},
+		{ExecuteCommandsRequest{PodName: "web-1", Namespace: "app", ErrorType: "OOM;Killed"}, false},
+	}
+
+	for _, tt := range tests {
+		err := validateTemplateFields(tt.req)
+		if tt.valid && err != nil {
+			t.Errorf("%+v: unexpected error %v", tt.req, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%+v: expected an error", tt.req)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package watcher
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// maxAnalysisEvidence caps how many warning events are cited in an analysis
+const maxAnalysisEvidence = 5
+
+// Analysis is the watcher's diagnosis of a pod, produced without attempting a fix
+type Analysis struct {
+	Cluster       string   `json:"cluster,omitempty"`
+	Namespace     string   `json:"namespace"`
+	PodName       string   `json:"pod_name"`
+	Phase         string   `json:"phase"`
+	Failed        bool     `json:"failed"`
+	ErrorType     string   `json:"error_type,omitempty"`
+	Cause         string   `json:"cause,omitempty"`
+	Action        string   `json:"action,omitempty"`
+	Evidence      []string `json:"evidence,omitempty"`
+	RecentChanges []string `json:"recent_changes,omitempty"`
+}
+
+// Analyze diagnoses a pod with the built-in heuristics without fixing it or calling the
+// reflexion service. A missing pod is reported as the API's NotFound error.
+func (pw *PodWatcher) Analyze(ctx context.Context, namespace, name string) (*Analysis, error) {
+	pod, err := pw.k8sClient.GetPod(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &Analysis{
+		Cluster:   pw.cluster,
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+		Phase:     string(pod.Status.Phase),
+		Failed:    pw.k8sClient.IsPodFailed(pod),
+	}
+	if !analysis.Failed {
+		return analysis, nil
+	}
+
+	analysis.ErrorType = pw.k8sClient.GetPodErrorType(pod)
+	diagnosis := diagnosePod(pod, analysis.ErrorType)
+	analysis.Cause = diagnosis.Cause
+	analysis.Action = diagnosis.Action
+
+	// Cite the most recent warning events as evidence
+	events, err := pw.k8sClient.GetPodEvents(ctx, pod.Namespace, pod.Name)
+	if err != nil {
+		pw.logger.Printf("⚠️  Failed to get events for analysis of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	for i := len(events) - 1; i >= 0 && len(analysis.Evidence) < maxAnalysisEvidence; i-- {
+		if events[i].Type == v1.EventTypeWarning {
+			analysis.Evidence = append(analysis.Evidence, events[i].Message)
+		}
+	}
+
+	for _, change := range pw.findRecentChanges(pod) {
+		analysis.RecentChanges = append(analysis.RecentChanges, change.String())
+	}
+
+	return analysis, nil
+}
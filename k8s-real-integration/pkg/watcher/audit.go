@@ -0,0 +1,34 @@
+package watcher
+
+import (
+	"k8s-real-integration-go/pkg/audit"
+)
+
+// SetAuditLogger sets a logger that records every fix attempt persistently
+func (pw *PodWatcher) SetAuditLogger(a *audit.AuditLogger) {
+	pw.audit = a
+}
+
+// auditFix appends a fix record to the audit log
+func (pw *PodWatcher) auditFix(record FixRecord) {
+	if pw.audit == nil {
+		return
+	}
+
+	err := pw.audit.Log(audit.Entry{
+		Timestamp: record.Timestamp,
+		Source:    audit.SourceWatcher,
+		Cluster:   record.Cluster,
+		Namespace: record.Namespace,
+		PodName:   record.PodName,
+		PodUID:    record.PodUID,
+		ErrorType: record.ErrorType,
+		Strategy:  record.Strategy,
+		Success:   record.Success,
+		DryRun:    pw.k8sClient.DryRun(),
+		Message:   record.Message,
+	})
+	if err != nil {
+		pw.logger.Printf("⚠️  Failed to audit fix for pod %s/%s: %v", record.Namespace, record.PodName, err)
+	}
+}
@@ -0,0 +1,67 @@
+package watcher
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Bare pod policies control what happens to failed pods that have no controlling owner.
+// Owner-managed pods are always handled by the normal fix flow; these only apply to bare pods.
+const (
+	BarePodPolicyRecreate = "recreate" // fix and recreate the pod (default)
+	BarePodPolicyDelete   = "delete"   // treat the pod as disposable and delete it
+	BarePodPolicySkip     = "skip"     // leave the pod alone
+)
+
+// ValidateBarePodPolicy checks that a bare pod policy is supported
+func ValidateBarePodPolicy(policy string) error {
+	switch policy {
+	case BarePodPolicyRecreate, BarePodPolicyDelete, BarePodPolicySkip:
+		return nil
+	}
+	return fmt.Errorf("invalid bare pod policy %q (expected recreate, delete or skip)", policy)
+}
+
+// SetBarePodPolicy sets how failed pods without a controlling owner are handled
+func (pw *PodWatcher) SetBarePodPolicy(policy string) {
+	pw.barePodPolicy = policy
+}
+
+// isBarePod reports whether a pod has no controlling owner
+func isBarePod(pod *v1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller {
+			return false
+		}
+	}
+	return true
+}
+
+// applyBarePodPolicy handles a failed bare pod according to the policy and reports
+// whether the normal fix flow should be skipped
+func (pw *PodWatcher) applyBarePodPolicy(pod *v1.Pod, errorType string) bool {
+	if !isBarePod(pod) {
+		return false
+	}
+
+	switch pw.barePodPolicy {
+	case BarePodPolicySkip:
+		pw.logger.Printf("⏭️  Skipping bare pod %s/%s (bare-pod-policy=skip)", pod.Namespace, pod.Name)
+		return true
+	case BarePodPolicyDelete:
+		record := pw.newFixRecord(pod, errorType, nil)
+		record.Strategy = "delete_bare_pod"
+		if err := pw.k8sClient.DeletePod(pw.ctx, pod.Namespace, pod.Name); err != nil {
+			pw.logger.Printf("❌ Failed to delete bare pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			record.Message = err.Error()
+		} else {
+			pw.logger.Printf("🗑️  Deleted bare pod %s/%s (bare-pod-policy=delete)", pod.Namespace, pod.Name)
+			record.Success = true
+		}
+		pw.recordFix(record)
+		return true
+	}
+
+	return false
+}
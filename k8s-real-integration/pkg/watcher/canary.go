@@ -0,0 +1,81 @@
+package watcher
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// rolloutHashLabel is set by Argo Rollouts on every pod it manages
+const rolloutHashLabel = "rollouts-pod-template-hash"
+
+// rolloutRoleLabels may mark the canary or preview pods of an Argo Rollout
+var rolloutRoleLabels = []string{"rollouts.argoproj.io/role", "role"}
+
+// SetFixCanaries allows fixing pods that belong to a canary or blue-green rollout
+func (pw *PodWatcher) SetFixCanaries(fixCanaries bool) {
+	pw.fixCanaries = fixCanaries
+}
+
+// canaryReason returns why a pod looks like part of a progressive-delivery rollout,
+// or an empty string if it does not
+func (pw *PodWatcher) canaryReason(pod *v1.Pod) string {
+	// Argo Rollouts labels every pod it manages, stable ones included
+	if hash, ok := pod.Labels[rolloutHashLabel]; ok {
+		return pw.rolloutCanaryReason(pod, hash)
+	}
+
+	// Flagger stamps the canary pod template while an analysis is running
+	if _, ok := pod.Annotations["flagger-id"]; ok {
+		return "canary under Flagger analysis"
+	}
+
+	// Common hand-rolled canary/blue-green conventions
+	if pod.Labels["track"] == "canary" || pod.Labels["canary"] == "true" {
+		return "labelled as a canary"
+	}
+
+	return ""
+}
+
+// rolloutCanaryReason reports an Argo Rollout pod that is not from the rollout's stable
+// ReplicaSet, i.e. a canary or blue-green preview pod. Stable pods are fixed like any other.
+func (pw *PodWatcher) rolloutCanaryReason(pod *v1.Pod, hash string) string {
+	for _, key := range rolloutRoleLabels {
+		if role := pod.Labels[key]; role == "canary" || role == "preview" {
+			return role + " pod of an Argo Rollout"
+		}
+	}
+
+	rollout := pw.rolloutName(pod)
+	if rollout == "" {
+		return ""
+	}
+	stableHash, err := pw.k8sClient.GetRolloutStableHash(pw.ctx, pod.Namespace, rollout)
+	if err != nil {
+		pw.logger.Printf("⚠️  Could not tell whether pod %s/%s is a canary: %v", pod.Namespace, pod.Name, err)
+		return ""
+	}
+	if hash != stableHash {
+		return "canary or preview pod of Argo Rollout " + rollout + " (stable is " + stableHash + ")"
+	}
+	return ""
+}
+
+// rolloutName returns the Argo Rollout that owns the pod's ReplicaSet, or an empty string
+func (pw *PodWatcher) rolloutName(pod *v1.Pod) string {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller == nil || !*ref.Controller || ref.Kind != "ReplicaSet" {
+			continue
+		}
+		replicaSet, err := pw.k8sClient.GetReplicaSet(pw.ctx, pod.Namespace, ref.Name)
+		if err != nil {
+			pw.logger.Printf("⚠️  Could not find the rollout of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			return ""
+		}
+		for _, owner := range replicaSet.OwnerReferences {
+			if owner.Controller != nil && *owner.Controller && owner.Kind == "Rollout" {
+				return owner.Name
+			}
+		}
+	}
+	return ""
+}
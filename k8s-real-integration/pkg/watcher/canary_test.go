@@ -0,0 +1,134 @@
+package watcher
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"k8s-real-integration-go/pkg/k8s"
+)
+
+// newRolloutWatcher returns a watcher talking to a fake API server that knows ReplicaSet
+// web-abc of Argo Rollout web, whose stable pod template hash is stableHash
+func newRolloutWatcher(t *testing.T, stableHash string) *PodWatcher {
+	t.Helper()
+	controller := true
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/apps/v1/namespaces/default/replicasets/web-abc":
+			json.NewEncoder(w).Encode(&appsv1.ReplicaSet{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+				ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default", OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout", Name: "web", Controller: &controller,
+				}}},
+			})
+		case "/apis/argoproj.io/v1alpha1/namespaces/default/rollouts/web":
+			if stableHash == "" {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"status": map[string]string{"stableRS": stableHash},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(ts.Close)
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: ts.URL})
+	if err != nil {
+		t.Fatalf("creating clientset: %v", err)
+	}
+	pw := NewPodWatcher(k8s.NewClientFromClientset(clientset), nil, "default")
+	t.Cleanup(pw.cancel)
+	return pw
+}
+
+// rolloutPod returns a pod of ReplicaSet web-abc with the given template hash and labels
+func rolloutPod(hash string, labels map[string]string) *v1.Pod {
+	pod := crashingPod("web-abc-1", "uid-00000001")
+	pod.Labels = map[string]string{rolloutHashLabel: hash}
+	for key, value := range labels {
+		pod.Labels[key] = value
+	}
+	return pod
+}
+
+func TestCanaryReasonArgoRollouts(t *testing.T) {
+	pw := newRolloutWatcher(t, "abc")
+
+	if reason := pw.canaryReason(rolloutPod("abc", nil)); reason != "" {
+		t.Errorf("a pod of the stable ReplicaSet should be fixed, got reason %q", reason)
+	}
+	if reason := pw.canaryReason(rolloutPod("def", nil)); !strings.Contains(reason, "Argo Rollout web") {
+		t.Errorf("a pod off the stable hash should be skipped, got reason %q", reason)
+	}
+	if reason := pw.canaryReason(rolloutPod("abc", map[string]string{"rollouts.argoproj.io/role": "preview"})); reason == "" {
+		t.Error("a pod with the preview role should be skipped")
+	}
+	if reason := pw.canaryReason(rolloutPod("abc", map[string]string{"role": "canary"})); reason == "" {
+		t.Error("a pod with the canary role should be skipped")
+	}
+}
+
+func TestCanaryReasonUnknownStableHash(t *testing.T) {
+	pw := newRolloutWatcher(t, "")
+
+	if reason := pw.canaryReason(rolloutPod("def", nil)); reason != "" {
+		t.Errorf("without a known stable hash only role labels should skip a pod, got reason %q", reason)
+	}
+}
+
+func TestCanaryReasonOtherConventions(t *testing.T) {
+	pw := NewPodWatcher(nil, nil, "default")
+	defer pw.cancel()
+
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		canary      bool
+	}{
+		{"plain pod", nil, nil, false},
+		{"flagger analysis", nil, map[string]string{"flagger-id": "42"}, true},
+		{"track label", map[string]string{"track": "canary"}, nil, true},
+		{"canary label", map[string]string{"canary": "true"}, nil, true},
+		{"stable track", map[string]string{"track": "stable"}, nil, false},
+	}
+
+	for _, tt := range tests {
+		pod := crashingPod("web-1", "uid-00000001")
+		pod.Labels = tt.labels
+		pod.Annotations = tt.annotations
+		if reason := pw.canaryReason(pod); (reason != "") != tt.canary {
+			t.Errorf("%s: canaryReason = %q, want canary %v", tt.name, reason, tt.canary)
+		}
+	}
+}
+
+func TestFixCanariesSkipsRolloutLookups(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	pw := NewPodWatcher(k8s.NewClientFromClientset(clientset), nil, "default")
+	defer pw.cancel()
+	pw.SetFixCanaries(true)
+	pw.SetOffline(true)
+
+	pod := rolloutPod("def", nil)
+	if action := pw.plannedAction(pod, "CrashLoopBackOff"); action != "diagnose (offline)" {
+		t.Errorf("plannedAction = %q, want the canary to be handled like any pod", action)
+	}
+	if actions := clientset.Actions(); len(actions) != 0 {
+		t.Errorf("--fix-canaries should not look up the pod's rollout, got %d API calls", len(actions))
+	}
+}
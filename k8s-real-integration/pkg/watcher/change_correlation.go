@@ -0,0 +1,157 @@
+package watcher
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recentChange is a modification to a pod's owner or configuration shortly before it failed
+type recentChange struct {
+	Kind      string
+	Name      string
+	ChangedAt time.Time
+	Detail    string
+}
+
+// String renders the change as a root-cause pointer
+func (c recentChange) String() string {
+	text := fmt.Sprintf("likely caused by recent change to %s %s at %s (%v before the failure was detected)",
+		c.Kind, c.Name, c.ChangedAt.Format(time.RFC3339), time.Since(c.ChangedAt).Round(time.Second))
+	if c.Detail != "" {
+		text += ": " + c.Detail
+	}
+	return text
+}
+
+// SetChangeCorrelationWindow looks for owner rollouts and ConfigMap/Secret changes made
+// within window before a failure. A window of 0 disables change correlation.
+func (pw *PodWatcher) SetChangeCorrelationWindow(window time.Duration) {
+	pw.changeWindow = window
+}
+
+// lastModified returns the latest time an object was created or written by any manager
+func lastModified(meta metav1.ObjectMeta) time.Time {
+	modified := meta.CreationTimestamp.Time
+	for _, entry := range meta.ManagedFields {
+		if entry.Time != nil && entry.Time.After(modified) {
+			modified = entry.Time.Time
+		}
+	}
+	return modified
+}
+
+// configReferences returns the names of ConfigMaps and Secrets a pod consumes
+func configReferences(pod *v1.Pod) (configMaps, secrets map[string]bool) {
+	configMaps = make(map[string]bool)
+	secrets = make(map[string]bool)
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.ConfigMap != nil {
+			configMaps[volume.ConfigMap.Name] = true
+		}
+		if volume.Secret != nil {
+			secrets[volume.Secret.SecretName] = true
+		}
+	}
+
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				configMaps[envFrom.ConfigMapRef.Name] = true
+			}
+			if envFrom.SecretRef != nil {
+				secrets[envFrom.SecretRef.Name] = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				configMaps[env.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				secrets[env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+	}
+
+	return configMaps, secrets
+}
+
+// findRecentChanges returns changes to the pod's rollout and configuration within the
+// correlation window, most recent first
+func (pw *PodWatcher) findRecentChanges(pod *v1.Pod) []recentChange {
+	if pw.changeWindow <= 0 {
+		return nil
+	}
+
+	since := time.Now().Add(-pw.changeWindow)
+	var changes []recentChange
+
+	// A recently created ReplicaSet means the Deployment's pod template just rolled out
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller == nil || !*ref.Controller || ref.Kind != "ReplicaSet" {
+			continue
+		}
+		replicaSet, err := pw.k8sClient.GetReplicaSet(pw.ctx, pod.Namespace, ref.Name)
+		if err != nil {
+			pw.logger.Printf("⚠️  Could not check rollout history for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			continue
+		}
+		if created := replicaSet.CreationTimestamp.Time; created.After(since) {
+			kind, name := "ReplicaSet", replicaSet.Name
+			for _, owner := range replicaSet.OwnerReferences {
+				if owner.Controller != nil && *owner.Controller {
+					kind, name = owner.Kind, owner.Name
+				}
+			}
+			detail := fmt.Sprintf("rolled out ReplicaSet %s", replicaSet.Name)
+			if revision := replicaSet.Annotations["deployment.kubernetes.io/revision"]; revision != "" {
+				detail += " (revision " + revision + ")"
+			}
+			changes = append(changes, recentChange{Kind: kind, Name: name, ChangedAt: created, Detail: detail})
+		}
+	}
+
+	configMaps, secrets := configReferences(pod)
+	for name := range configMaps {
+		configMap, err := pw.k8sClient.GetConfigMap(pw.ctx, pod.Namespace, name)
+		if err != nil {
+			continue // a missing ConfigMap is reported by the error type itself
+		}
+		if modified := lastModified(configMap.ObjectMeta); modified.After(since) {
+			changes = append(changes, recentChange{Kind: "ConfigMap", Name: name, ChangedAt: modified})
+		}
+	}
+	for name := range secrets {
+		secret, err := pw.k8sClient.GetSecret(pw.ctx, pod.Namespace, name)
+		if err != nil {
+			continue
+		}
+		if modified := lastModified(secret.ObjectMeta); modified.After(since) {
+			changes = append(changes, recentChange{Kind: "Secret", Name: name, ChangedAt: modified})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ChangedAt.After(changes[j].ChangedAt)
+	})
+	return changes
+}
+
+// correlateRecentChanges logs changes that likely caused the failure and returns them as
+// root-cause hints for the analysis
+func (pw *PodWatcher) correlateRecentChanges(pod *v1.Pod) []string {
+	var hints []string
+	for _, change := range pw.findRecentChanges(pod) {
+		hint := change.String()
+		pw.logger.Printf("🕒 Pod %s/%s: %s", pod.Namespace, pod.Name, hint)
+		hints = append(hints, hint)
+	}
+	return hints
+}
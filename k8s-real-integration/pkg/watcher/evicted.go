@@ -0,0 +1,35 @@
+package watcher
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// SetForceDeleteEvicted allows deleting evicted pods that have no controlling owner.
+// Nothing reschedules such pods, so by default they are only reported.
+func (pw *PodWatcher) SetForceDeleteEvicted(force bool) {
+	pw.forceEvicted = force
+}
+
+// handleEvicted cleans up an evicted pod. Recreating it would only produce another copy on
+// a node under pressure; deleting it lets its controller schedule a replacement.
+func (pw *PodWatcher) handleEvicted(pod *v1.Pod) {
+	pw.logger.Printf("🧹 Pod %s/%s was evicted: %s", pod.Namespace, pod.Name, pod.Status.Message)
+
+	if isBarePod(pod) && !pw.forceEvicted {
+		pw.logger.Printf("⚠️  Not deleting evicted pod %s/%s: it has no controller to replace it (use --force-delete-evicted to override)",
+			pod.Namespace, pod.Name)
+		return
+	}
+
+	record := pw.newFixRecord(pod, "Evicted", nil)
+	record.Strategy = "delete_evicted_pod"
+	if err := pw.k8sClient.DeletePod(pw.ctx, pod.Namespace, pod.Name); err != nil {
+		pw.logger.Printf("❌ Failed to delete evicted pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		record.Message = err.Error()
+	} else {
+		pw.logger.Printf("🗑️  Deleted evicted pod %s/%s", pod.Namespace, pod.Name)
+		record.Success = true
+		record.Message = pod.Status.Message
+	}
+	pw.recordFix(record)
+}
@@ -0,0 +1,110 @@
+package watcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Reflexion service feedback endpoints, relative to the service's base URL
+const (
+	feedbackPath      = "/api/v1/reflexion/execution-feedback"
+	feedbackBatchPath = "/api/v1/reflexion/execution-feedback/batch"
+)
+
+// feedbackBatcher accumulates execution feedback and sends it as one array payload
+type feedbackBatcher struct {
+	baseURL  string
+	maxItems int
+	window   time.Duration
+	pending  []map[string]interface{}
+	timer    *time.Timer
+	mutex    sync.Mutex
+}
+
+// SetFeedbackBatching batches execution feedback, sending it when maxItems are pending or
+// window has passed since the first pending item. A maxItems of 0 sends feedback immediately.
+func (pw *PodWatcher) SetFeedbackBatching(maxItems int, window time.Duration) {
+	if maxItems <= 0 {
+		pw.feedback = nil
+		return
+	}
+	pw.feedback = &feedbackBatcher{
+		baseURL:  pw.reflexionClient.BaseURL(),
+		maxItems: maxItems,
+		window:   window,
+	}
+}
+
+// add queues a feedback item and flushes once the batch is full
+func (b *feedbackBatcher) add(item map[string]interface{}) {
+	b.mutex.Lock()
+	b.pending = append(b.pending, item)
+	full := len(b.pending) >= b.maxItems
+	if !full && b.timer == nil {
+		b.timer = time.AfterFunc(b.window, b.flush)
+	}
+	b.mutex.Unlock()
+
+	if full {
+		b.flush()
+	}
+}
+
+// flush sends all pending feedback, falling back to per-item sends when the
+// batch endpoint is not available
+func (b *feedbackBatcher) flush() {
+	b.mutex.Lock()
+	items := b.pending
+	b.pending = nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	b.mutex.Unlock()
+
+	if len(items) == 0 {
+		return
+	}
+
+	status, err := postFeedback(b.baseURL+feedbackBatchPath, items)
+	if err == nil {
+		log.Printf("✅ Execution feedback batch of %d items sent for reflexion learning", len(items))
+		return
+	}
+
+	if status == http.StatusNotFound || status == http.StatusMethodNotAllowed {
+		log.Printf("⚠️  Feedback batch endpoint not available, sending %d items individually", len(items))
+	} else {
+		log.Printf("⚠️  Failed to send feedback batch, sending %d items individually: %v", len(items), err)
+	}
+
+	for _, item := range items {
+		if _, err := postFeedback(b.baseURL+feedbackPath, item); err != nil {
+			log.Printf("❌ Failed to send execution feedback for workflow %v: %v", item["workflow_id"], err)
+		}
+	}
+}
+
+// postFeedback posts a feedback payload to the Python service and returns the HTTP status
+func postFeedback(url string, payload interface{}) (int, error) {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal feedback: %v", err)
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return 0, fmt.Errorf("failed to send feedback to Python service: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, fmt.Errorf("Python service returned status %d for feedback", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
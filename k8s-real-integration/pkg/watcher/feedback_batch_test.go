@@ -0,0 +1,97 @@
+package watcher
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// feedbackServer records the feedback payloads the batcher posts
+type feedbackServer struct {
+	mutex        sync.Mutex
+	batches      [][]map[string]interface{}
+	singles      []map[string]interface{}
+	batchMissing bool
+}
+
+func (f *feedbackServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	switch r.URL.Path {
+	case feedbackBatchPath:
+		if f.batchMissing {
+			http.NotFound(w, r)
+			return
+		}
+		var batch []map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&batch)
+		f.batches = append(f.batches, batch)
+	case feedbackPath:
+		var item map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&item)
+		f.singles = append(f.singles, item)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestFeedbackBatcherSendsFullBatch(t *testing.T) {
+	server := &feedbackServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	b := &feedbackBatcher{baseURL: ts.URL, maxItems: 2, window: time.Hour}
+	b.add(map[string]interface{}{"workflow_id": "a"})
+	b.add(map[string]interface{}{"workflow_id": "b"})
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if len(server.batches) != 1 || len(server.batches[0]) != 2 {
+		t.Fatalf("expected one batch of 2 items, got %v", server.batches)
+	}
+	if len(server.singles) != 0 {
+		t.Errorf("expected no single posts, got %d", len(server.singles))
+	}
+}
+
+func TestFeedbackBatcherFallsBackToSingleItems(t *testing.T) {
+	server := &feedbackServer{batchMissing: true}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	b := &feedbackBatcher{baseURL: ts.URL, maxItems: 10, window: time.Hour}
+	b.add(map[string]interface{}{"workflow_id": "a"})
+	b.add(map[string]interface{}{"workflow_id": "b"})
+	b.flush()
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if len(server.singles) != 2 {
+		t.Fatalf("expected 2 single posts after the batch endpoint 404s, got %d", len(server.singles))
+	}
+}
+
+func TestFeedbackBatcherFlushesAfterWindow(t *testing.T) {
+	server := &feedbackServer{}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	b := &feedbackBatcher{baseURL: ts.URL, maxItems: 10, window: 20 * time.Millisecond}
+	b.add(map[string]interface{}{"workflow_id": "a"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		server.mutex.Lock()
+		sent := len(server.batches)
+		server.mutex.Unlock()
+		if sent == 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("batch was not sent after the window elapsed")
+}
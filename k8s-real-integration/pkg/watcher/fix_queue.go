@@ -0,0 +1,137 @@
+package watcher
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// FixQueue collects the failed pods found by every watcher of a cluster and fixes them
+// one at a time, most severe first, so a cluster watched namespace by namespace is still
+// handled in cluster-wide priority order
+type FixQueue struct {
+	pending map[string]*queuedPod // pod key -> pod waiting to be fixed
+	seq     int64
+	mutex   sync.Mutex
+	wake    chan struct{}
+	stopCh  chan struct{}
+}
+
+// queuedPod is a failed pod waiting in a FixQueue, with the watcher that found it
+type queuedPod struct {
+	watcher   *PodWatcher
+	pod       *v1.Pod
+	errorType string
+	workload  string
+	seq       int64
+}
+
+// NewFixQueue creates an empty fix queue; Start runs its worker
+func NewFixQueue() *FixQueue {
+	return &FixQueue{
+		pending: make(map[string]*queuedPod),
+		wake:    make(chan struct{}, 1),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// SetFixQueue makes the watcher hand failed pods to q instead of fixing them itself;
+// all watchers of one cluster should share one queue
+func (pw *PodWatcher) SetFixQueue(q *FixQueue) {
+	pw.fixQueue = q
+}
+
+// Start begins fixing queued pods
+func (q *FixQueue) Start() {
+	go q.run()
+}
+
+// Stop stops the worker; pods still queued are left for the next run
+func (q *FixQueue) Stop() {
+	close(q.stopCh)
+}
+
+// enqueue adds a failed pod found by pw. A pod already waiting is updated in place and
+// keeps its position among pods of equal severity.
+func (q *FixQueue) enqueue(pw *PodWatcher, pod *v1.Pod) {
+	podKey := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, pod.UID)
+	item := &queuedPod{
+		watcher:   pw,
+		pod:       pod,
+		errorType: pw.k8sClient.GetPodErrorType(pod),
+		workload:  pw.workloadKey(pod),
+	}
+
+	q.mutex.Lock()
+	if existing, ok := q.pending[podKey]; ok {
+		item.seq = existing.seq
+	} else {
+		q.seq++
+		item.seq = q.seq
+	}
+	q.pending[podKey] = item
+	depth := len(q.pending)
+	q.mutex.Unlock()
+
+	pw.metrics.setQueueDepth(pw.cluster, depth)
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next removes and returns the most severe queued pod, or nil when the queue is empty.
+// Failing replicas are counted across every namespace's pods in the queue.
+func (q *FixQueue) next() *queuedPod {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	replicas := make(map[string]int)
+	for _, item := range q.pending {
+		replicas[item.workload]++
+	}
+
+	var bestKey string
+	var best *queuedPod
+	bestScore := 0
+	for podKey, item := range q.pending {
+		score := severityScore(item.errorType, item.pod, replicas[item.workload])
+		if best == nil || score > bestScore || (score == bestScore && item.seq < best.seq) {
+			bestKey, best, bestScore = podKey, item, score
+		}
+	}
+	if best != nil {
+		delete(q.pending, bestKey)
+		best.watcher.metrics.setQueueDepth(best.watcher.cluster, len(q.pending))
+	}
+	return best
+}
+
+// run fixes queued pods until the queue is stopped
+func (q *FixQueue) run() {
+	for {
+		select {
+		case <-q.stopCh:
+			return
+		default:
+		}
+
+		item := q.next()
+		if item == nil {
+			select {
+			case <-q.stopCh:
+				return
+			case <-q.wake:
+			}
+			continue
+		}
+
+		// The watcher may have stopped, or the pod been handled, while it waited
+		pw := item.watcher
+		if pw.ctx.Err() != nil || pw.isProcessed(item.pod) {
+			continue
+		}
+		pw.processPod(item.pod)
+	}
+}
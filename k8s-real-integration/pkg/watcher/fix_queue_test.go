@@ -0,0 +1,43 @@
+package watcher
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s-real-integration-go/pkg/k8s"
+)
+
+func TestFixQueueOrdersAcrossNamespaces(t *testing.T) {
+	client := k8s.NewClientFromClientset(fake.NewSimpleClientset())
+	teamA := NewPodWatcher(client, nil, "team-a")
+	defer teamA.cancel()
+	teamB := NewPodWatcher(client, nil, "team-b")
+	defer teamB.cancel()
+
+	imagePull := crashingPod("a-image", "uid-00000001")
+	imagePull.Namespace = "team-a"
+	imagePull.Status.ContainerStatuses[0].RestartCount = 0
+	imagePull.Status.ContainerStatuses[0].State = v1.ContainerState{
+		Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+	}
+	crashing := crashingPod("b-crash", "uid-00000002")
+	crashing.Namespace = "team-b"
+
+	q := NewFixQueue()
+	q.enqueue(teamA, imagePull)
+	q.enqueue(teamB, crashing)
+	q.enqueue(teamA, imagePull) // seen again by the watch; must not be queued twice
+
+	first, second := q.next(), q.next()
+	if first == nil || first.pod.Name != "b-crash" || first.watcher != teamB {
+		t.Fatalf("first queued pod = %+v, want b-crash from team-b", first)
+	}
+	if second == nil || second.pod.Name != "a-image" || second.watcher != teamA {
+		t.Fatalf("second queued pod = %+v, want a-image from team-a", second)
+	}
+	if extra := q.next(); extra != nil {
+		t.Errorf("a re-enqueued pod was queued twice: %s", extra.pod.Name)
+	}
+}
@@ -0,0 +1,119 @@
+package watcher
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// heuristicDiagnosis holds the built-in explanation and recommended action for an error type
+type heuristicDiagnosis struct {
+	Cause  string
+	Action string
+}
+
+// heuristics maps error types to built-in diagnoses used when running offline
+var heuristics = map[string]heuristicDiagnosis{
+	"ImagePullBackOff": {
+		Cause:  "Image could not be pulled (missing tag, wrong registry or missing credentials)",
+		Action: "Verify the image name and tag exist in the registry, or add an imagePullSecret",
+	},
+	"InitContainerImagePullBackOff": {
+		Cause:  "Init container image could not be pulled",
+		Action: "Verify the init container image name and tag exist in the registry",
+	},
+	"InvalidImageName": {
+		Cause:  "Image reference is not a valid image name",
+		Action: "Correct the image reference format (registry/repository:tag)",
+	},
+	"InitContainerInvalidImageName": {
+		Cause:  "Init container image reference is not a valid image name",
+		Action: "Correct the init container image reference format",
+	},
+	"CrashLoopBackOff": {
+		Cause:  "Container keeps exiting after start",
+		Action: "Inspect the previous container logs and fix the command, arguments or configuration",
+	},
+	"OOMKilled": {
+		Cause:  "Container exceeded its memory limit",
+		Action: "Increase the container memory limit or reduce the application's memory usage",
+	},
+	"Segfault": {
+		Cause:  "Container crashed with a segmentation fault (exit code 139)",
+		Action: "Check the application binary and base image compatibility",
+	},
+	"SIGTERM": {
+		Cause:  "Container was terminated by SIGTERM (exit code 143)",
+		Action: "Check liveness probe settings and graceful shutdown handling",
+	},
+	"CreateContainerConfigError": {
+		Cause:  "Container configuration references a missing ConfigMap, Secret or key",
+		Action: "Create the referenced ConfigMap/Secret or fix the reference",
+	},
+	"CreateContainerError": {
+		Cause:  "Container runtime could not create the container",
+		Action: "Check the container command, volume mounts and security context",
+	},
+	"ConfigError": {
+		Cause:  "Container configuration is invalid",
+		Action: "Review the container spec for invalid fields",
+	},
+	"RunContainerError": {
+		Cause:  "Container runtime could not start the container",
+		Action: "Check the entrypoint/command exists in the image",
+	},
+	"ContainerCannotRun": {
+		Cause:  "Container runtime could not run the container",
+		Action: "Check the entrypoint/command exists in the image",
+	},
+	"NoCommandSpecified": {
+		Cause:  "Image has no default entrypoint and the pod spec does not set a command",
+		Action: "Specify spec.containers[].command (or use an image with an ENTRYPOINT/CMD)",
+	},
+	"InitContainerFailed": {
+		Cause:  "Init container exited with a non-zero code",
+		Action: "Inspect the init container logs and fix its command or dependencies",
+	},
+	"PodPending": {
+		Cause:  "Pod could not be scheduled or started within 60 seconds",
+		Action: "Check scheduling events for resource, taint or volume problems",
+	},
+	"PodFailed": {
+		Cause:  "Pod reached the Failed phase",
+		Action: "Inspect pod events and container logs",
+	},
+	"Evicted": {
+		Cause:  "Pod was evicted by the kubelet, usually because of node memory or disk pressure",
+		Action: "Delete the evicted pod and check the node's resource pressure and the pod's requests",
+	},
+}
+
+// diagnose returns the built-in diagnosis for an error type
+func diagnose(errorType string) heuristicDiagnosis {
+	if diagnosis, ok := heuristics[errorType]; ok {
+		return diagnosis
+	}
+	return heuristicDiagnosis{
+		Cause:  "Unrecognized failure",
+		Action: "Inspect pod events and container logs manually",
+	}
+}
+
+// reportHeuristicDiagnosis logs a built-in diagnosis for a failed pod without calling external services
+func (pw *PodWatcher) reportHeuristicDiagnosis(pod *v1.Pod, events []v1.Event, errorType string) {
+	podKey := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+	diagnosis := diagnosePod(pod, errorType)
+
+	pw.logger.Printf("🧭 Offline diagnosis for pod %s:", podKey)
+	pw.logger.Printf("   🏷️  Error Type: %s", errorType)
+	pw.logger.Printf("   🔍 Likely Cause: %s", diagnosis.Cause)
+	pw.logger.Printf("   🛠️  Recommended Action: %s", diagnosis.Action)
+
+	// Cite the most recent warning event as evidence
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Type == v1.EventTypeWarning {
+			pw.logger.Printf("   📎 Evidence: %s", events[i].Message)
+			break
+		}
+	}
+}
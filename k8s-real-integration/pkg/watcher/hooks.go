@@ -0,0 +1,99 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// defaultHookTimeout bounds a hook command when no timeout is configured
+const defaultHookTimeout = 30 * time.Second
+
+// fixHooks are shell commands run after a fix succeeds or fails
+type fixHooks struct {
+	onSuccess string
+	onFailure string
+	timeout   time.Duration
+}
+
+// SetHooks configures commands run after each fix attempt. The fix details are passed
+// as FIX_* environment variables; an empty command disables that hook.
+func (pw *PodWatcher) SetHooks(onSuccess, onFailure string, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+	pw.hooks = &fixHooks{
+		onSuccess: onSuccess,
+		onFailure: onFailure,
+		timeout:   timeout,
+	}
+}
+
+// hookEnv returns the environment passed to hook commands for a fix record
+func hookEnv(record FixRecord) []string {
+	result := "failure"
+	if record.Success {
+		result = "success"
+	}
+
+	return append(os.Environ(),
+		"FIX_RESULT="+result,
+		"FIX_CLUSTER="+record.Cluster,
+		"FIX_NAMESPACE="+record.Namespace,
+		"FIX_POD="+record.PodName,
+		"FIX_POD_UID="+record.PodUID,
+		"FIX_ERROR_TYPE="+record.ErrorType,
+		"FIX_STRATEGY="+record.Strategy,
+		"FIX_CONFIDENCE="+fmt.Sprintf("%.2f", record.Confidence),
+		"FIX_WORKFLOW_ID="+record.WorkflowID,
+		"FIX_REQUIRES_HUMAN="+fmt.Sprintf("%t", record.RequiresHumanIntervention),
+		"FIX_MESSAGE="+record.Message,
+	)
+}
+
+// runHooks runs the success or failure hook for a fix record in the background
+func (pw *PodWatcher) runHooks(record FixRecord) {
+	if pw.hooks == nil {
+		return
+	}
+
+	name, command := "on-failure", pw.hooks.onFailure
+	if record.Success {
+		name, command = "on-success", pw.hooks.onSuccess
+	}
+	if command == "" {
+		return
+	}
+
+	go pw.runHook(name, command, record)
+}
+
+// runHook executes a hook command with a timeout and logs its output
+func (pw *PodWatcher) runHook(name, command string, record FixRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), pw.hooks.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = hookEnv(record)
+
+	startTime := time.Now()
+	output, err := cmd.CombinedOutput()
+	duration := time.Since(startTime)
+
+	if trimmed := strings.TrimSpace(string(output)); trimmed != "" {
+		pw.logger.Printf("🪝 %s hook output for pod %s/%s:\n%s", name, record.Namespace, record.PodName, trimmed)
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		pw.logger.Printf("⏰ %s hook for pod %s/%s timed out after %v", name, record.Namespace, record.PodName, pw.hooks.timeout)
+		return
+	}
+	if err != nil {
+		pw.logger.Printf("❌ %s hook for pod %s/%s failed: %v", name, record.Namespace, record.PodName, err)
+		return
+	}
+	pw.logger.Printf("🪝 %s hook for pod %s/%s completed in %v", name, record.Namespace, record.PodName, duration)
+}
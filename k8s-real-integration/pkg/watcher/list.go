@@ -0,0 +1,157 @@
+package watcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Failed pod list formats accepted by --output
+const (
+	ListFormatTable = "table"
+	ListFormatJSON  = "json"
+)
+
+// ValidateListFormat checks that format is a known failed pod list format
+func ValidateListFormat(format string) error {
+	switch format {
+	case ListFormatTable, ListFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q (must be %s or %s)", format, ListFormatTable, ListFormatJSON)
+	}
+}
+
+// FailedPod is a pod the watcher would act on, with the action it would take
+type FailedPod struct {
+	Cluster   string `json:"cluster,omitempty"`
+	Namespace string `json:"namespace"`
+	PodName   string `json:"pod_name"`
+	ErrorType string `json:"error_type"`
+	Restarts  int32  `json:"restarts"`
+	Action    string `json:"action"`
+}
+
+// ListFailedPods lists the failed pods in the watched namespace without changing anything
+func (pw *PodWatcher) ListFailedPods(ctx context.Context) ([]FailedPod, error) {
+	pods, err := pw.k8sClient.ListPods(ctx, pw.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var failed []FailedPod
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == v1.PodSucceeded || pod.DeletionTimestamp != nil || !pw.k8sClient.IsPodFailed(pod) {
+			continue
+		}
+
+		errorType := pw.k8sClient.GetPodErrorType(pod)
+		var restarts int32
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			restarts += containerStatus.RestartCount
+		}
+
+		failed = append(failed, FailedPod{
+			Cluster:   pw.cluster,
+			Namespace: pod.Namespace,
+			PodName:   pod.Name,
+			ErrorType: errorType,
+			Restarts:  restarts,
+			Action:    pw.plannedAction(pod, errorType),
+		})
+	}
+	return failed, nil
+}
+
+// plannedAction describes what processPod would do with a failed pod under the current
+// settings. The checks follow processPod's early exits in the same order.
+func (pw *PodWatcher) plannedAction(pod *v1.Pod, errorType string) string {
+	if errorType == "Evicted" {
+		if isBarePod(pod) && !pw.forceEvicted {
+			return "report (evicted bare pod)"
+		}
+		return "delete (evicted)"
+	}
+	if !pw.fixCanaries && pw.canaryReason(pod) != "" {
+		return "skip (canary)"
+	}
+	switch errorType {
+	case "CreateContainerConfigError", "CreateContainerError", "ConfigError":
+		if _, missing, explained := pw.checkMissingReference(pod); explained {
+			if missing == "" {
+				return "wait (reference now exists)"
+			}
+			return "report (missing reference)"
+		}
+	}
+
+	_, recurred := fixedByAgent(pod)
+	switch {
+	case pw.oscillationStopped(pod, errorType):
+		return "skip (oscillating)"
+	case recurred && !pw.refix:
+		return "report (failed again after agent fix)"
+	case isBarePod(pod) && pw.barePodPolicy == BarePodPolicySkip:
+		return "skip (bare pod)"
+	case isBarePod(pod) && pw.barePodPolicy == BarePodPolicyDelete:
+		return "delete (bare pod)"
+	case pw.offline:
+		return "diagnose (offline)"
+	default:
+		return "fix"
+	}
+}
+
+// RenderFailedPods renders failed pods grouped by cluster and namespace
+func RenderFailedPods(pods []FailedPod, format string) (string, error) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		if pods[i].Cluster != pods[j].Cluster {
+			return pods[i].Cluster < pods[j].Cluster
+		}
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].PodName < pods[j].PodName
+	})
+
+	switch format {
+	case ListFormatJSON:
+		if pods == nil {
+			pods = []FailedPod{}
+		}
+		data, err := json.MarshalIndent(pods, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal failed pods: %w", err)
+		}
+		return string(data) + "\n", nil
+	case ListFormatTable:
+		if len(pods) == 0 {
+			return "No failed pods found\n", nil
+		}
+
+		var buf bytes.Buffer
+		tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAMESPACE\tPOD\tERROR\tRESTARTS\tACTION")
+		for i, pod := range pods {
+			namespace := pod.Namespace
+			if pod.Cluster != "" {
+				namespace = pod.Cluster + ":" + namespace
+			}
+			// Print each namespace once to group its pods
+			if i > 0 && pods[i-1].Cluster == pod.Cluster && pods[i-1].Namespace == pod.Namespace {
+				namespace = ""
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", namespace, pod.PodName, pod.ErrorType, pod.Restarts, pod.Action)
+		}
+		tw.Flush()
+		return buf.String(), nil
+	default:
+		return "", ValidateListFormat(format)
+	}
+}
@@ -0,0 +1,47 @@
+package watcher
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPlannedActionMirrorsProcessPod(t *testing.T) {
+	missingConfig := crashingPod("web-config", "uid-00000003")
+	missingConfig.Status.ContainerStatuses[0].State = v1.ContainerState{Waiting: &v1.ContainerStateWaiting{
+		Reason:  "CreateContainerConfigError",
+		Message: `configmap "app-config" not found`,
+	}}
+
+	tests := []struct {
+		name      string
+		pod       *v1.Pod
+		errorType string
+		configure func(pw *PodWatcher)
+		want      string
+	}{
+		{"failing pod", crashingPod("web-1", "uid-00000001"), "CrashLoopBackOff", nil, "fix"},
+		{"missing ConfigMap", missingConfig, "CreateContainerConfigError", nil, "report (missing reference)"},
+		{"failed again after agent fix", agentFixedPod("web-2", "uid-00000002"), "CrashLoopBackOff", nil,
+			"report (failed again after agent fix)"},
+		{"failed again with --refix", agentFixedPod("web-2", "uid-00000002"), "CrashLoopBackOff",
+			func(pw *PodWatcher) { pw.SetRefix(true) }, "fix"},
+		{"oscillating workload", crashingPod("web-1", "uid-00000001"), "CrashLoopBackOff", func(pw *PodWatcher) {
+			pw.SetOscillationLimit(1, time.Hour)
+			pw.oscillation.escalated["default/Deployment/web/CrashLoopBackOff"] = time.Now()
+		}, "skip (oscillating)"},
+		{"offline", crashingPod("web-1", "uid-00000001"), "CrashLoopBackOff",
+			func(pw *PodWatcher) { pw.SetOffline(true) }, "diagnose (offline)"},
+	}
+
+	for _, tt := range tests {
+		pw, _ := newTestWatcher(t, tt.pod)
+		if tt.configure != nil {
+			tt.configure(pw)
+		}
+		if got := pw.plannedAction(tt.pod, tt.errorType); got != tt.want {
+			t.Errorf("%s: plannedAction = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
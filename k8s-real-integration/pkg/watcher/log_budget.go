@@ -0,0 +1,123 @@
+package watcher
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"k8s-real-integration-go/pkg/k8s"
+)
+
+// crashLogOptions fetch enough output to include a full stack trace or panic
+var crashLogOptions = k8s.LogOptions{TailLines: 200, MaxBytes: 128 * 1024}
+
+// quickLogOptions are enough to confirm a container never produced useful output
+var quickLogOptions = k8s.LogOptions{TailLines: 20, MaxBytes: 16 * 1024}
+
+// logBytesPerLine sizes the byte budget of a --log-lines override, up to maxOverrideLogBytes
+const (
+	logBytesPerLine     = 512
+	maxOverrideLogBytes = 1024 * 1024
+)
+
+// SetLogOptions overrides how much container log is fetched for analysis: the last tailLines
+// lines (0 keeps the per-error-type defaults, capped at k8s.MaxTailLines) from within the last
+// since (0 means no time limit)
+func (pw *PodWatcher) SetLogOptions(tailLines int64, since time.Duration) {
+	pw.logTailLines = min(tailLines, k8s.MaxTailLines)
+	pw.logSince = since
+}
+
+// logOptions returns the log budget for an error type with the configured overrides applied
+func (pw *PodWatcher) logOptions(errorType string) k8s.LogOptions {
+	opts := logOptionsFor(errorType)
+	if pw.logTailLines > 0 {
+		opts.TailLines = pw.logTailLines
+		opts.MaxBytes = min(max(opts.MaxBytes, pw.logTailLines*logBytesPerLine), maxOverrideLogBytes)
+	}
+	if pw.logSince > 0 {
+		opts.SinceSeconds = int64(pw.logSince.Seconds())
+	}
+	return opts
+}
+
+// logOptionsFor picks how much log output to fetch for a failure. Crashes need the tail
+// of the log for the stack trace; failures before the container ran need very little.
+func logOptionsFor(errorType string) k8s.LogOptions {
+	switch errorType {
+	case "CrashLoopBackOff", "Segfault", "SIGTERM", "OOMKilled", "PodFailed":
+		return crashLogOptions
+	case "ImagePullBackOff", "InvalidImageName", "InitContainerImagePullBackOff", "InitContainerInvalidImageName",
+		"CreateContainerConfigError", "CreateContainerError", "ConfigError", "NoCommandSpecified", "PodPending":
+		return quickLogOptions
+	default:
+		return k8s.DefaultLogOptions
+	}
+}
+
+// failingContainer returns the name of the first container that is not ready and is waiting
+// or terminated, checking init containers first. An empty result selects the first container.
+func failingContainer(pod *v1.Pod) string {
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.Ready {
+			continue
+		}
+		if status.State.Waiting != nil || status.State.Terminated != nil {
+			return status.Name
+		}
+	}
+	return ""
+}
+
+// containerLogs fetches the failing container's logs for analysis. After a crash the
+// restarted container has usually logged little, so the previous instance's output is
+// used instead when there is one. Containers that never started have no logs to fetch.
+func (pw *PodWatcher) containerLogs(pod *v1.Pod, errorType string) []string {
+	podKey := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+	container := failingContainer(pod)
+	status := containerStatus(pod, container)
+	opts := pw.logOptions(errorType)
+
+	if status != nil && status.State.Running == nil && status.State.Terminated == nil && status.LastTerminationState.Terminated == nil {
+		return []string{"No logs available: container has not started"}
+	}
+
+	if status != nil && status.LastTerminationState.Terminated != nil {
+		previous := opts
+		previous.Previous = true
+		logs, err := pw.k8sClient.GetPodLogs(pw.ctx, pod.Namespace, pod.Name, container, previous)
+		if err == nil && len(logs) > 0 {
+			return logs
+		}
+		if err != nil {
+			pw.logger.Printf("⚠️  Failed to get previous container logs for pod %s, using current logs: %v", podKey, err)
+		}
+	}
+
+	logs, err := pw.k8sClient.GetPodLogs(pw.ctx, pod.Namespace, pod.Name, container, opts)
+	if err != nil {
+		pw.logger.Printf("❌ Failed to get logs for pod %s: %v", podKey, err)
+		return []string{"Failed to retrieve logs"}
+	}
+	return logs
+}
+
+// containerStatus returns the status of the named init or app container; an empty name
+// selects the first app container
+func containerStatus(pod *v1.Pod, name string) *v1.ContainerStatus {
+	if name == "" {
+		if len(pod.Spec.Containers) == 0 {
+			return nil
+		}
+		name = pod.Spec.Containers[0].Name
+	}
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
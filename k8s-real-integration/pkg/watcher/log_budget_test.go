@@ -0,0 +1,54 @@
+package watcher
+
+import (
+	"testing"
+	"time"
+
+	"k8s-real-integration-go/pkg/k8s"
+)
+
+func TestLogOptionsDefaultsPerErrorType(t *testing.T) {
+	pw := NewPodWatcher(nil, nil, "default")
+
+	if got := pw.logOptions("CrashLoopBackOff"); got != crashLogOptions {
+		t.Errorf("CrashLoopBackOff: got %+v, want %+v", got, crashLogOptions)
+	}
+	if got := pw.logOptions("ImagePullBackOff"); got != quickLogOptions {
+		t.Errorf("ImagePullBackOff: got %+v, want %+v", got, quickLogOptions)
+	}
+	if got := pw.logOptions("Unknown"); got != k8s.DefaultLogOptions {
+		t.Errorf("Unknown: got %+v, want %+v", got, k8s.DefaultLogOptions)
+	}
+}
+
+func TestLogOptionsOverrides(t *testing.T) {
+	pw := NewPodWatcher(nil, nil, "default")
+	pw.SetLogOptions(1000, 10*time.Minute)
+
+	got := pw.logOptions("ImagePullBackOff")
+	if got.TailLines != 1000 {
+		t.Errorf("TailLines = %d, want 1000", got.TailLines)
+	}
+	if got.SinceSeconds != 600 {
+		t.Errorf("SinceSeconds = %d, want 600", got.SinceSeconds)
+	}
+	if want := int64(1000 * logBytesPerLine); got.MaxBytes != want {
+		t.Errorf("MaxBytes = %d, want %d", got.MaxBytes, want)
+	}
+}
+
+func TestLogOptionsOverridesAreCapped(t *testing.T) {
+	pw := NewPodWatcher(nil, nil, "default")
+	pw.SetLogOptions(10*k8s.MaxTailLines, 0)
+
+	got := pw.logOptions("CrashLoopBackOff")
+	if got.TailLines != k8s.MaxTailLines {
+		t.Errorf("TailLines = %d, want %d", got.TailLines, k8s.MaxTailLines)
+	}
+	if got.MaxBytes != maxOverrideLogBytes {
+		t.Errorf("MaxBytes = %d, want %d", got.MaxBytes, maxOverrideLogBytes)
+	}
+	if got.SinceSeconds != 0 {
+		t.Errorf("SinceSeconds = %d, want 0", got.SinceSeconds)
+	}
+}
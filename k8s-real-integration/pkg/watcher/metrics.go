@@ -0,0 +1,148 @@
+package watcher
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// fixDurationBuckets are the upper bounds (seconds) of the fix duration histogram; AI
+// analysis usually takes tens of seconds
+var fixDurationBuckets = []float64{1, 5, 10, 30, 60, 120, 300}
+
+// Metrics collects counters and gauges from one or more pod watchers in its own
+// Prometheus registry
+type Metrics struct {
+	registry       *prometheus.Registry
+	errorsDetected *prometheus.CounterVec
+	fixesAttempted *prometheus.CounterVec
+	fixesSucceeded *prometheus.CounterVec
+	fixesFailed    *prometheus.CounterVec
+	fixDuration    *prometheus.HistogramVec
+	queueDepth     *prometheus.GaugeVec
+	fixesInFlight  *prometheus.GaugeVec
+	circuitOpen    *prometheus.GaugeVec
+}
+
+// NewMetrics creates a metrics collector with Go runtime and process metrics registered
+func NewMetrics() *Metrics {
+	byErrorType := []string{"cluster", "error_type"}
+	byCluster := []string{"cluster"}
+
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		errorsDetected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "k8s_agent_errors_detected_total",
+			Help: "Failed pods detected, by error type",
+		}, byErrorType),
+		fixesAttempted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "k8s_agent_fixes_attempted_total",
+			Help: "Fix attempts, by error type",
+		}, byErrorType),
+		fixesSucceeded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "k8s_agent_fixes_succeeded_total",
+			Help: "Successful fix attempts, by error type",
+		}, byErrorType),
+		fixesFailed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "k8s_agent_fixes_failed_total",
+			Help: "Failed fix attempts (including ones needing human intervention), by error type",
+		}, byErrorType),
+		fixDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "k8s_agent_fix_duration_seconds",
+			Help:    "Time spent analyzing and fixing a pod",
+			Buckets: fixDurationBuckets,
+		}, byCluster),
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "k8s_agent_queue_depth",
+			Help: "Failed pods from the current scan still waiting to be processed",
+		}, byCluster),
+		fixesInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "k8s_agent_fixes_in_progress",
+			Help: "Fixes currently in progress",
+		}, byCluster),
+		circuitOpen: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "k8s_agent_reflexion_circuit_open",
+			Help: "Whether the reflexion circuit breaker is open (1) or closed (0)",
+		}, byCluster),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.errorsDetected,
+		m.fixesAttempted,
+		m.fixesSucceeded,
+		m.fixesFailed,
+		m.fixDuration,
+		m.queueDepth,
+		m.fixesInFlight,
+		m.circuitOpen,
+	)
+	return m
+}
+
+// SetMetrics makes the watcher report to m; watchers of several clusters may share one collector
+func (pw *PodWatcher) SetMetrics(m *Metrics) {
+	pw.metrics = m
+}
+
+// Handler serves the collected metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// errorDetected counts a failed pod picked up for processing
+func (m *Metrics) errorDetected(cluster, errorType string) {
+	if m == nil {
+		return
+	}
+	m.errorsDetected.WithLabelValues(cluster, errorType).Inc()
+}
+
+// fixRecorded counts the outcome of a fix attempt
+func (m *Metrics) fixRecorded(record FixRecord) {
+	if m == nil {
+		return
+	}
+	m.fixesAttempted.WithLabelValues(record.Cluster, record.ErrorType).Inc()
+	if record.Success {
+		m.fixesSucceeded.WithLabelValues(record.Cluster, record.ErrorType).Inc()
+	} else {
+		m.fixesFailed.WithLabelValues(record.Cluster, record.ErrorType).Inc()
+	}
+}
+
+// fixStarted marks a fix as in flight and returns a function that ends it and records its duration
+func (m *Metrics) fixStarted(cluster string) func() {
+	if m == nil {
+		return func() {}
+	}
+	m.fixesInFlight.WithLabelValues(cluster).Inc()
+	timer := prometheus.NewTimer(m.fixDuration.WithLabelValues(cluster))
+	return func() {
+		timer.ObserveDuration()
+		m.fixesInFlight.WithLabelValues(cluster).Dec()
+	}
+}
+
+// setQueueDepth records how many failed pods from the current scan are still waiting
+func (m *Metrics) setQueueDepth(cluster string, depth int) {
+	if m == nil {
+		return
+	}
+	m.queueDepth.WithLabelValues(cluster).Set(float64(depth))
+}
+
+// setCircuitOpen records whether the reflexion circuit breaker is refusing requests
+func (m *Metrics) setCircuitOpen(cluster string, open bool) {
+	if m == nil {
+		return
+	}
+	value := 0.0
+	if open {
+		value = 1
+	}
+	m.circuitOpen.WithLabelValues(cluster).Set(value)
+}
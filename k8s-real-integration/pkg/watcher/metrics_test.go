@@ -0,0 +1,58 @@
+package watcher
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// scrape returns the metrics page served by m
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatalf("reading metrics: %v", err)
+	}
+	return string(body)
+}
+
+func TestMetricsExposition(t *testing.T) {
+	m := NewMetrics()
+	m.errorDetected("prod", "CrashLoopBackOff")
+	m.errorDetected("prod", "CrashLoopBackOff")
+	m.fixRecorded(FixRecord{Cluster: "prod", ErrorType: "CrashLoopBackOff", Success: true})
+	m.fixRecorded(FixRecord{Cluster: "prod", ErrorType: `bad"type\`, Success: false})
+	m.setQueueDepth("prod", 3)
+	m.setCircuitOpen("prod", true)
+	m.fixStarted("prod")()
+
+	page := scrape(t, m)
+	for _, want := range []string{
+		`k8s_agent_errors_detected_total{cluster="prod",error_type="CrashLoopBackOff"} 2`,
+		`k8s_agent_fixes_attempted_total{cluster="prod",error_type="CrashLoopBackOff"} 1`,
+		`k8s_agent_fixes_succeeded_total{cluster="prod",error_type="CrashLoopBackOff"} 1`,
+		`k8s_agent_fixes_failed_total{cluster="prod",error_type="bad\"type\\"} 1`,
+		`k8s_agent_queue_depth{cluster="prod"} 3`,
+		`k8s_agent_reflexion_circuit_open{cluster="prod"} 1`,
+		`k8s_agent_fixes_in_progress{cluster="prod"} 0`,
+		`k8s_agent_fix_duration_seconds_bucket{cluster="prod",le="1"} 1`,
+		`k8s_agent_fix_duration_seconds_count{cluster="prod"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("metrics page is missing %s", want)
+		}
+	}
+}
+
+func TestMetricsNilCollectorIsNoop(t *testing.T) {
+	var m *Metrics
+	m.errorDetected("prod", "OOMKilled")
+	m.fixRecorded(FixRecord{})
+	m.setQueueDepth("prod", 1)
+	m.setCircuitOpen("prod", true)
+	m.fixStarted("prod")()
+}
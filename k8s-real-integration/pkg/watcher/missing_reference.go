@@ -0,0 +1,129 @@
+package watcher
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var (
+	// missingObjectPattern matches the kubelet's `configmap "app-config" not found`
+	missingObjectPattern = regexp.MustCompile(`(?i)\b(configmap|secret) "([^"]+)" not found`)
+	// missingKeyPattern matches the kubelet's `couldn't find key password in Secret ns/db-creds`
+	missingKeyPattern = regexp.MustCompile(`(?i)couldn't find key (\S+) in (ConfigMap|Secret) (?:([^/\s]+)/)?(\S+)`)
+)
+
+// configReference is a ConfigMap or Secret (and optionally one of its keys) named by a config error
+type configReference struct {
+	Kind string // "ConfigMap" or "Secret"
+	Name string
+	Key  string
+}
+
+// String describes the reference the way kubectl users know it
+func (r configReference) String() string {
+	if r.Key != "" {
+		return fmt.Sprintf("key %q of %s %q", r.Key, r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s %q", r.Kind, r.Name)
+}
+
+// parseConfigReference extracts the ConfigMap or Secret named in a config error message
+func parseConfigReference(message string) (configReference, bool) {
+	if match := missingKeyPattern.FindStringSubmatch(message); match != nil {
+		return configReference{Kind: canonicalKind(match[2]), Name: match[4], Key: match[1]}, true
+	}
+	if match := missingObjectPattern.FindStringSubmatch(message); match != nil {
+		return configReference{Kind: canonicalKind(match[1]), Name: match[2]}, true
+	}
+	return configReference{}, false
+}
+
+// canonicalKind normalizes the kind spelling used in kubelet messages
+func canonicalKind(kind string) string {
+	if strings.EqualFold(kind, "configmap") {
+		return "ConfigMap"
+	}
+	return "Secret"
+}
+
+// configKeys returns the keys of the referenced ConfigMap or Secret. A missing object is
+// reported as the API's NotFound error.
+func (pw *PodWatcher) configKeys(namespace string, ref configReference) (map[string]bool, error) {
+	keys := make(map[string]bool)
+	if ref.Kind == "ConfigMap" {
+		configMap, err := pw.k8sClient.GetConfigMap(pw.ctx, namespace, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		for key := range configMap.Data {
+			keys[key] = true
+		}
+		for key := range configMap.BinaryData {
+			keys[key] = true
+		}
+		return keys, nil
+	}
+
+	secret, err := pw.k8sClient.GetSecret(pw.ctx, namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	for key := range secret.Data {
+		keys[key] = true
+	}
+	for key := range secret.StringData {
+		keys[key] = true
+	}
+	return keys, nil
+}
+
+// checkMissingReference checks the ConfigMap or Secret named in a config error against the
+// cluster. It reports whether the failure is explained by the reference, and describes what
+// is missing; an empty description means the reference exists by now.
+func (pw *PodWatcher) checkMissingReference(pod *v1.Pod) (configReference, string, bool) {
+	ref, ok := parseConfigReference(configErrorMessage(pod))
+	if !ok {
+		return ref, "", false
+	}
+
+	keys, err := pw.configKeys(pod.Namespace, ref)
+	switch {
+	case apierrors.IsNotFound(err):
+		return ref, fmt.Sprintf("%s %q not found in namespace %q", ref.Kind, ref.Name, pod.Namespace), true
+	case err != nil:
+		pw.logger.Printf("⚠️  Could not check %s for pod %s/%s: %v", ref, pod.Namespace, pod.Name, err)
+		return ref, "", false
+	case ref.Key != "" && !keys[ref.Key]:
+		return ref, fmt.Sprintf("%s %q in namespace %q has no key %q", ref.Kind, ref.Name, pod.Namespace, ref.Key), true
+	}
+	return ref, "", true
+}
+
+// diagnoseMissingReference records an actionable result for a config error caused by a
+// ConfigMap or Secret reference. It returns true when the failure was fully explained, in
+// which case there is nothing for the reflexion service to fix.
+func (pw *PodWatcher) diagnoseMissingReference(pod *v1.Pod, errorType string) bool {
+	ref, missing, explained := pw.checkMissingReference(pod)
+	if !explained {
+		return false
+	}
+
+	record := pw.newFixRecord(pod, errorType, nil)
+	if missing == "" {
+		// Created since the kubelet last tried; the next container start will pick it up
+		pw.logger.Printf("🔗 %s for pod %s/%s now exists; the kubelet will retry starting the container", ref, pod.Namespace, pod.Name)
+		record.Informational = true
+		record.Message = fmt.Sprintf("%s now exists", ref)
+	} else {
+		pw.logger.Printf("🔗 Missing reference for pod %s/%s: %s", pod.Namespace, pod.Name, missing)
+		pw.logger.Printf("   🛠️  Recommended Action: create the %s or fix the reference in the pod spec", strings.ToLower(ref.Kind))
+		record.RequiresHumanIntervention = true
+		record.Message = missing
+	}
+	pw.recordFix(record)
+	return true
+}
@@ -0,0 +1,101 @@
+package watcher
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// OOMNodeTracker correlates OOMKilled pods by the node they ran on. Nodes are shared by
+// every namespace of a cluster, so all watchers of one cluster should share one tracker.
+type OOMNodeTracker struct {
+	threshold int
+	window    time.Duration
+	cordon    bool
+	kills     map[string]map[string]time.Time // node -> pod key -> last OOMKilled time
+	alerted   map[string]bool
+	mutex     sync.Mutex
+}
+
+// NewOOMNodeTracker creates a node-level OOMKilled correlation tracker.
+// A threshold of 0 disables it; cordon marks the node unschedulable once the threshold is hit.
+func NewOOMNodeTracker(threshold int, window time.Duration, cordon bool) *OOMNodeTracker {
+	return &OOMNodeTracker{
+		threshold: threshold,
+		window:    window,
+		cordon:    cordon,
+		kills:     make(map[string]map[string]time.Time),
+		alerted:   make(map[string]bool),
+	}
+}
+
+// SetOOMNodeTracker sets the tracker OOMKilled pods are correlated in
+func (pw *PodWatcher) SetOOMNodeTracker(t *OOMNodeTracker) {
+	pw.oomNodes = t
+}
+
+// recordOOMKill records an OOMKilled pod against its node and returns the number of
+// distinct pods OOMKilled on that node within the window, and whether an alert should fire
+func (t *OOMNodeTracker) recordOOMKill(nodeName, podKey string, now time.Time) (int, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	pods, ok := t.kills[nodeName]
+	if !ok {
+		pods = make(map[string]time.Time)
+		t.kills[nodeName] = pods
+	}
+	pods[podKey] = now
+
+	// Drop kills that fell out of the correlation window
+	for key, killedAt := range pods {
+		if now.Sub(killedAt) > t.window {
+			delete(pods, key)
+		}
+	}
+
+	count := len(pods)
+	if count < t.threshold {
+		t.alerted[nodeName] = false
+		return count, false
+	}
+	if t.alerted[nodeName] {
+		return count, false
+	}
+	t.alerted[nodeName] = true
+	return count, true
+}
+
+// correlateOOMKill checks whether OOMKilled pods are clustering on the pod's node
+func (pw *PodWatcher) correlateOOMKill(pod *v1.Pod) {
+	if pw.oomNodes == nil || pw.oomNodes.threshold <= 0 || pod.Spec.NodeName == "" {
+		return
+	}
+
+	podKey := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+	nodeName := pod.Spec.NodeName
+
+	count, alert := pw.oomNodes.recordOOMKill(nodeName, podKey, time.Now())
+	if !alert {
+		pw.logger.Printf("🧮 Node %s: %d OOMKilled pods within %v (threshold %d)",
+			nodeName, count, pw.oomNodes.window, pw.oomNodes.threshold)
+		return
+	}
+
+	pw.logger.Printf("🚨🚨 HIGH PRIORITY: %d pods OOMKilled on node %s within %v", count, nodeName, pw.oomNodes.window)
+	pw.logger.Printf("   🖥️  The node may be over-committed or running a leaking daemon")
+
+	if !pw.oomNodes.cordon {
+		pw.logger.Printf("   💡 Consider cordoning the node: kubectl cordon %s", nodeName)
+		return
+	}
+
+	reason := fmt.Sprintf("%d pods OOMKilled within %v", count, pw.oomNodes.window)
+	if err := pw.k8sClient.CordonNode(pw.ctx, nodeName, reason); err != nil {
+		pw.logger.Printf("❌ Failed to cordon node %s: %v", nodeName, err)
+		return
+	}
+	pw.logger.Printf("🚧 Node %s cordoned: %s", nodeName, reason)
+}
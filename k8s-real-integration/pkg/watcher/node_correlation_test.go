@@ -0,0 +1,28 @@
+package watcher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOOMNodeTrackerSharedAcrossNamespaces(t *testing.T) {
+	tracker := NewOOMNodeTracker(2, time.Minute, false)
+	var watchers []*PodWatcher
+	for _, namespace := range []string{"team-a", "team-b"} {
+		pw := NewPodWatcher(nil, nil, namespace)
+		defer pw.cancel()
+		pw.SetOOMNodeTracker(tracker)
+		watchers = append(watchers, pw)
+	}
+
+	for _, pw := range watchers {
+		pod := crashingPod("web-1", "uid-00000001")
+		pod.Namespace = pw.namespace
+		pod.Spec.NodeName = "worker-1"
+		pw.correlateOOMKill(pod)
+	}
+
+	if !tracker.alerted["worker-1"] {
+		t.Error("OOM kills in two namespaces on one node should reach the threshold of the shared tracker")
+	}
+}
@@ -0,0 +1,45 @@
+package watcher
+
+import (
+	"k8s-real-integration-go/pkg/notifier"
+)
+
+// SetNotifier sets a notifier that is told about every fix outcome
+func (pw *PodWatcher) SetNotifier(n notifier.Notifier) {
+	pw.notifier = n
+}
+
+// notificationFor converts a fix record into a notification
+func notificationFor(record FixRecord) notifier.Notification {
+	outcome := notifier.OutcomeFailure
+	switch {
+	case record.RequiresHumanIntervention:
+		outcome = notifier.OutcomeManual
+	case record.Success:
+		outcome = notifier.OutcomeSuccess
+	}
+
+	return notifier.Notification{
+		Cluster:   record.Cluster,
+		Namespace: record.Namespace,
+		Pod:       record.PodName,
+		ErrorType: record.ErrorType,
+		Action:    record.Strategy,
+		Outcome:   outcome,
+		Success:   record.Success,
+		Message:   record.Message,
+	}
+}
+
+// notify sends the outcome of a fix to the configured notifier in the background
+func (pw *PodWatcher) notify(record FixRecord) {
+	if pw.notifier == nil {
+		return
+	}
+
+	go func() {
+		if err := pw.notifier.Notify(notificationFor(record)); err != nil {
+			pw.logger.Printf("⚠️  Failed to send notification for pod %s/%s: %v", record.Namespace, record.PodName, err)
+		}
+	}()
+}
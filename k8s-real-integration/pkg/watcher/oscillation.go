@@ -0,0 +1,143 @@
+package watcher
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// oscillationTracker records repeated failures per workload across pod recreations
+type oscillationTracker struct {
+	limit     int
+	window    time.Duration
+	failures  map[string][]time.Time // workload/error type -> failure times
+	escalated map[string]time.Time   // workload/error type -> time of escalation
+	mutex     sync.Mutex
+}
+
+// SetOscillationLimit stops auto-fixing a workload once the same error recurs after agent
+// fixes more than limit times within window. The stop lasts for one window.
+// A limit of 0 disables oscillation detection.
+func (pw *PodWatcher) SetOscillationLimit(limit int, window time.Duration) {
+	pw.oscillation = &oscillationTracker{
+		limit:     limit,
+		window:    window,
+		failures:  make(map[string][]time.Time),
+		escalated: make(map[string]time.Time),
+	}
+}
+
+// workloadKey identifies a pod by its top-level controller so that recreated pods share
+// history. Pods of a Deployment are keyed by the Deployment rather than the ReplicaSet,
+// which changes on every rollout.
+func (pw *PodWatcher) workloadKey(pod *v1.Pod) string {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller == nil || !*ref.Controller {
+			continue
+		}
+		if ref.Kind == "ReplicaSet" {
+			replicaSet, err := pw.k8sClient.GetReplicaSet(pw.ctx, pod.Namespace, ref.Name)
+			if err == nil {
+				for _, owner := range replicaSet.OwnerReferences {
+					if owner.Controller != nil && *owner.Controller {
+						return fmt.Sprintf("%s/%s/%s", pod.Namespace, owner.Kind, owner.Name)
+					}
+				}
+			}
+		}
+		return fmt.Sprintf("%s/%s/%s", pod.Namespace, ref.Kind, ref.Name)
+	}
+	return fmt.Sprintf("%s/Pod/%s", pod.Namespace, pod.Name)
+}
+
+// isEscalated reports whether auto-fixing is stopped for key. An escalation expires
+// one window after it was raised, and the failure history starts over.
+func (t *oscillationTracker) isEscalated(key string, now time.Time) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	escalatedAt, ok := t.escalated[key]
+	if !ok {
+		return false
+	}
+	if now.Sub(escalatedAt) > t.window {
+		delete(t.escalated, key)
+		delete(t.failures, key)
+		return false
+	}
+	return true
+}
+
+// record adds a failure and returns the recurrence count within the window and
+// whether this failure escalated the workload
+func (t *oscillationTracker) record(key string, now time.Time) (int, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var recent []time.Time
+	for _, failedAt := range t.failures[key] {
+		if now.Sub(failedAt) <= t.window {
+			recent = append(recent, failedAt)
+		}
+	}
+	recent = append(recent, now)
+	t.failures[key] = recent
+
+	if len(recent) > t.limit {
+		t.escalated[key] = now
+		return len(recent), true
+	}
+	return len(recent), false
+}
+
+// oscillationStopped reports whether auto-fixing is currently stopped for the pod's workload
+func (pw *PodWatcher) oscillationStopped(pod *v1.Pod, errorType string) bool {
+	if pw.oscillation == nil || pw.oscillation.limit <= 0 {
+		return false
+	}
+	return pw.oscillation.isEscalated(pw.workloadKey(pod)+"/"+errorType, time.Now())
+}
+
+// checkOscillation records a failure that followed an agent fix and reports whether
+// auto-fixing should stop for the workload
+func (pw *PodWatcher) checkOscillation(pod *v1.Pod, errorType string) bool {
+	if pw.oscillation == nil || pw.oscillation.limit <= 0 {
+		return false
+	}
+
+	workload := pw.workloadKey(pod)
+	key := workload + "/" + errorType
+	now := time.Now()
+	if pw.oscillation.isEscalated(key, now) {
+		pw.logger.Printf("🚨 Auto-fixing of %s is stopped after oscillating with %s - skipping pod %s",
+			workload, errorType, pod.Name)
+		return true
+	}
+
+	// Only failures after our own fixes show that the fixes are not holding
+	if _, recurred := fixedByAgent(pod); !recurred {
+		return false
+	}
+
+	count, escalated := pw.oscillation.record(key, now)
+	if !escalated {
+		if count > 1 {
+			pw.logger.Printf("🔁 Workload %s failed with %s %d times within %v after agent fixes",
+				workload, errorType, count, pw.oscillation.window)
+		}
+		return false
+	}
+
+	pw.logger.Printf("🚨 Oscillation detected: workload %s failed with %s %d times within %v despite fixes",
+		workload, errorType, count, pw.oscillation.window)
+	pw.logger.Printf("🚨 Human intervention required - auto-fixing stopped for %s for %v", workload, pw.oscillation.window)
+
+	record := pw.newFixRecord(pod, errorType, nil)
+	record.RequiresHumanIntervention = true
+	record.Message = fmt.Sprintf("oscillation: %s failed with %s %d times within %v after agent fixes; auto-fixing stopped",
+		workload, errorType, count, pw.oscillation.window)
+	pw.recordFix(record)
+	return true
+}
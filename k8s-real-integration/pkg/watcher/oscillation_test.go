@@ -0,0 +1,106 @@
+package watcher
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s-real-integration-go/pkg/k8s"
+)
+
+// agentFixedPod returns a crashing pod carrying the markers of a fresh agent fix
+func agentFixedPod(name, uid string) *v1.Pod {
+	pod := crashingPod(name, uid)
+	pod.Labels = map[string]string{
+		fixedByMarker:      fixedByValue,
+		fixTimestampMarker: time.Now().Format(fixTimestampLayout),
+	}
+	return pod
+}
+
+func TestWorkloadKeyUsesTopLevelOwner(t *testing.T) {
+	controller := true
+	deploymentOwner := []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: &controller}}
+	client := k8s.NewClientFromClientset(fake.NewSimpleClientset(
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default", OwnerReferences: deploymentOwner}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-def", Namespace: "default", OwnerReferences: deploymentOwner}},
+	))
+	pw := NewPodWatcher(client, nil, "default")
+	defer pw.cancel()
+
+	before := crashingPod("web-abc-1", "uid-00000001")
+	after := crashingPod("web-def-1", "uid-00000002")
+	after.OwnerReferences[0].Name = "web-def"
+
+	if got := pw.workloadKey(before); got != "default/Deployment/web" {
+		t.Errorf("workloadKey = %q, want default/Deployment/web", got)
+	}
+	if pw.workloadKey(before) != pw.workloadKey(after) {
+		t.Error("pods of the same Deployment across a rollout should share a workload key")
+	}
+
+	bare := crashingPod("standalone", "uid-00000003")
+	bare.OwnerReferences = nil
+	if got := pw.workloadKey(bare); got != "default/Pod/standalone" {
+		t.Errorf("workloadKey = %q, want default/Pod/standalone", got)
+	}
+}
+
+func TestCheckOscillationCountsOnlyFailuresAfterAgentFixes(t *testing.T) {
+	pw, _ := newTestWatcher(t, crashingPod("web-1", "uid-00000001"))
+	pw.SetOscillationLimit(1, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if pw.checkOscillation(crashingPod("web-1", "uid-00000001"), "CrashLoopBackOff") {
+			t.Fatal("failures without an agent fix should not count towards oscillation")
+		}
+	}
+
+	if pw.checkOscillation(agentFixedPod("web-2", "uid-00000002"), "CrashLoopBackOff") {
+		t.Fatal("the first failure after an agent fix should not escalate with limit 1")
+	}
+	if !pw.checkOscillation(agentFixedPod("web-3", "uid-00000003"), "CrashLoopBackOff") {
+		t.Fatal("the second failure after agent fixes should escalate with limit 1")
+	}
+
+	fixes := pw.GetRecentFixes()
+	if len(fixes) != 1 || !fixes[0].RequiresHumanIntervention || fixes[0].Message == "" {
+		t.Fatalf("expected one fix record requiring human intervention, got %+v", fixes)
+	}
+
+	// Once escalated, every pod of the workload is skipped, marked or not
+	if !pw.checkOscillation(crashingPod("web-4", "uid-00000004"), "CrashLoopBackOff") {
+		t.Error("an escalated workload should stay stopped within the window")
+	}
+	if len(pw.GetRecentFixes()) != 1 {
+		t.Error("the escalation should be recorded only once")
+	}
+}
+
+func TestOscillationEscalationExpires(t *testing.T) {
+	tracker := &oscillationTracker{
+		limit:     1,
+		window:    time.Minute,
+		failures:  make(map[string][]time.Time),
+		escalated: make(map[string]time.Time),
+	}
+	start := time.Now()
+
+	tracker.record("key", start)
+	if _, escalated := tracker.record("key", start.Add(time.Second)); !escalated {
+		t.Fatal("expected the second failure to escalate")
+	}
+	if !tracker.isEscalated("key", start.Add(30*time.Second)) {
+		t.Error("escalation should hold within the window")
+	}
+	if tracker.isEscalated("key", start.Add(2*time.Minute)) {
+		t.Error("escalation should expire after the window")
+	}
+	if count, escalated := tracker.record("key", start.Add(2*time.Minute)); count != 1 || escalated {
+		t.Errorf("history should start over after expiry, got count %d escalated %v", count, escalated)
+	}
+}
@@ -0,0 +1,45 @@
+package watcher
+
+// Pause stops the watcher from applying fixes; failed pods are still detected and logged
+func (pw *PodWatcher) Pause() {
+	if !pw.paused.Swap(true) {
+		pw.logger.Printf("⏸️  Pod watcher paused - fixes will not be applied until resumed")
+	}
+}
+
+// Resume re-enables fixes; pods detected while paused are handled on the next scan
+func (pw *PodWatcher) Resume() {
+	if !pw.paused.Swap(false) {
+		return
+	}
+
+	pw.mutex.Lock()
+	deferred := len(pw.deferredPods)
+	pw.deferredPods = make(map[string]bool)
+	pw.mutex.Unlock()
+
+	pw.logger.Printf("▶️  Pod watcher resumed - %d pods deferred while paused will be processed", deferred)
+}
+
+// IsPaused reports whether fixes are currently paused
+func (pw *PodWatcher) IsPaused() bool {
+	return pw.paused.Load()
+}
+
+// deferWhilePaused logs a failed pod once while paused and reports whether processing
+// should stop. Deferred pods are not marked processed, so they are fixed after Resume.
+func (pw *PodWatcher) deferWhilePaused(podKey, errorType string) bool {
+	if !pw.paused.Load() {
+		return false
+	}
+
+	pw.mutex.Lock()
+	alreadyDeferred := pw.deferredPods[podKey]
+	pw.deferredPods[podKey] = true
+	pw.mutex.Unlock()
+
+	if !alreadyDeferred {
+		pw.logger.Printf("⏸️  Detected failed pod %s (Error: %s) - fix deferred while paused", podKey, errorType)
+	}
+	return true
+}
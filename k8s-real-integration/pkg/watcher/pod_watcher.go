@@ -2,43 +2,129 @@ package watcher
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
 
+	"k8s-real-integration-go/pkg/audit"
 	"k8s-real-integration-go/pkg/k8s"
+	"k8s-real-integration-go/pkg/notifier"
+	"k8s-real-integration-go/pkg/publisher"
 	"k8s-real-integration-go/pkg/reflexion"
 )
 
 // PodWatcher monitors Kubernetes pods for errors
 type PodWatcher struct {
-	k8sClient       *k8s.Client
-	reflexionClient *reflexion.Client
-	namespace       string
-	processedPods   map[string]bool
-	mutex           sync.RWMutex
-	stopCh          chan struct{}
+	k8sClient        *k8s.Client
+	reflexionClient  *reflexion.Client
+	namespace        string
+	processedPods    map[string]bool
+	mutex            sync.RWMutex
+	stopCh           chan struct{}
+	offline          bool
+	oomNodes         *OOMNodeTracker
+	oscillation      *oscillationTracker
+	fixCanaries      bool
+	cluster          string
+	logger           *log.Logger
+	scanInterval     time.Duration
+	fullScanEvery    time.Duration
+	recentFixes      *fixRing
+	sessionCounts    sessionCounter
+	barePodPolicy    string
+	publisher        publisher.Publisher
+	stallTimeout     time.Duration
+	lastActivity     atomic.Int64
+	processing       atomic.Int32
+	stalled          atomic.Bool
+	watchGeneration  atomic.Int64
+	hooks            *fixHooks
+	paused           atomic.Bool
+	deferredPods     map[string]bool
+	stuckTerminating *stuckTerminatingTracker
+	refix            bool
+	changeWindow     time.Duration
+	metrics          *Metrics
+	notifier         notifier.Notifier
+	forceEvicted     bool
+	ctx              context.Context
+	cancel           context.CancelFunc
+	audit            *audit.AuditLogger
+	executeURL       string
+	feedback         *feedbackBatcher
+	logTailLines     int64
+	logSince         time.Duration
+	fixQueue         *FixQueue
 }
 
 // NewPodWatcher creates a new pod watcher
 func NewPodWatcher(k8sClient *k8s.Client, reflexionClient *reflexion.Client, namespace string) *PodWatcher {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &PodWatcher{
 		k8sClient:       k8sClient,
 		reflexionClient: reflexionClient,
 		namespace:       namespace,
 		processedPods:   make(map[string]bool),
+		deferredPods:    make(map[string]bool),
 		stopCh:          make(chan struct{}),
+		logger:          log.New(log.Writer(), "", log.Flags()),
+		scanInterval:    10 * time.Second,
+		fullScanEvery:   5 * time.Minute,
+		recentFixes:     newFixRing(defaultRecentFixesSize),
+		barePodPolicy:   BarePodPolicyRecreate,
+		stallTimeout:    defaultStallTimeout,
+		ctx:             ctx,
+		cancel:          cancel,
 	}
 }
 
+// SetScanIntervals configures the delay before re-establishing a failed watch and how often a full periodic scan runs
+func (pw *PodWatcher) SetScanIntervals(scanInterval, fullScanInterval time.Duration) {
+	if scanInterval > 0 {
+		pw.scanInterval = scanInterval
+	}
+	if fullScanInterval > 0 {
+		pw.fullScanEvery = fullScanInterval
+	}
+}
+
+// SetCluster labels the watcher's log lines with a cluster name for multi-cluster runs
+func (pw *PodWatcher) SetCluster(cluster string) {
+	pw.cluster = cluster
+	pw.logger.SetPrefix(fmt.Sprintf("[%s] ", cluster))
+	pw.logger.SetFlags(log.Flags() | log.Lmsgprefix)
+}
+
+// GetCluster returns the cluster name the watcher is labelled with
+func (pw *PodWatcher) GetCluster() string {
+	return pw.cluster
+}
+
+// SetOffline enables heuristic-only mode where no external services are called
+func (pw *PodWatcher) SetOffline(offline bool) {
+	pw.offline = offline
+}
+
+// SetCommandExecution makes the watcher generate kubectl commands for each AI strategy and run
+// them through the execute-commands endpoint at executeURL. An empty URL keeps YAML manifest
+// mode, where the reflexion service applies fixes itself.
+func (pw *PodWatcher) SetCommandExecution(executeURL string) {
+	pw.executeURL = executeURL
+}
+
 // Start begins watching pods
 func (pw *PodWatcher) Start() error {
-	log.Printf("🔍 Starting pod watcher for namespace: %s", pw.namespace)
+	pw.logger.Printf("🔍 Starting pod watcher for namespace: %s", pw.namespace)
 
 	// Test connection first
 	if err := pw.k8sClient.TestConnection(); err != nil {
@@ -46,97 +132,217 @@ func (pw *PodWatcher) Start() error {
 	}
 
 	// Start the watch loop
-	go pw.watchLoop()
+	pw.markActivity()
+	go pw.watchLoop(pw.watchGeneration.Load())
+
+	// Restart the watch loop if it wedges
+	if pw.stallTimeout > 0 {
+		go pw.watchdog()
+	}
 
 	// Start periodic full scan
 	go pw.periodicScan()
 
-	log.Printf("✅ Pod watcher started successfully")
+	pw.logger.Printf("✅ Pod watcher started successfully")
 	return nil
 }
 
 // Stop stops the pod watcher
 func (pw *PodWatcher) Stop() {
-	log.Printf("🛑 Stopping pod watcher...")
+	pw.logger.Printf("🛑 Stopping pod watcher...")
 	close(pw.stopCh)
+	pw.cancel() // abort in-flight API calls
+	if pw.feedback != nil {
+		pw.feedback.flush() // don't drop queued execution feedback
+	}
 }
 
+// maxWatchTimeout is the longest a single watch stream stays open before it is re-established
+const maxWatchTimeout = 5 * time.Minute
+
 // watchLoop continuously watches for pod changes
-func (pw *PodWatcher) watchLoop() {
+func (pw *PodWatcher) watchLoop(generation int64) {
+	var resourceVersion string
 	for {
 		select {
 		case <-pw.stopCh:
-			log.Printf("📴 Pod watcher stopped")
+			pw.logger.Printf("📴 Pod watcher stopped")
 			return
 		default:
-			if err := pw.performWatch(); err != nil {
-				log.Printf("❌ Watch error: %v", err)
-				time.Sleep(5 * time.Second) // Wait before retry
+			var err error
+			if resourceVersion, err = pw.performWatch(generation, resourceVersion); err != nil {
+				if errors.Is(err, errWatchSuperseded) {
+					pw.logger.Printf("🐕 Stale watch loop exited after watchdog restart")
+					return
+				}
+				pw.logger.Printf("❌ Watch error: %v", err)
+				resourceVersion = ""        // list again before the next watch
+				time.Sleep(pw.scanInterval) // Wait before retry
 			}
 		}
 	}
 }
 
-// performWatch performs the actual pod watching
-func (pw *PodWatcher) performWatch() error {
-	// Get clientset (this is a simplified approach)
-	// In a real implementation, you'd use the proper watch API
+// watchTimeout bounds each watch stream so a silently dead connection is replaced well
+// before the watchdog would consider the loop wedged
+func (pw *PodWatcher) watchTimeout() time.Duration {
+	if pw.stallTimeout > 0 && pw.stallTimeout/2 < maxWatchTimeout {
+		return pw.stallTimeout / 2
+	}
+	return maxWatchTimeout
+}
 
-	// For now, we'll use a polling approach
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
+// performWatch streams pod changes from the API server until the watch closes and
+// returns the resource version to resume from. Without a resource version it first
+// lists the pods, so failures that already exist are handled in priority order rather
+// than in the order the watch would replay them.
+func (pw *PodWatcher) performWatch(generation int64, resourceVersion string) (string, error) {
+	if resourceVersion == "" {
+		pw.processing.Add(1)
+		listVersion, err := pw.scanPods()
+		pw.processing.Add(-1)
+		if err != nil {
+			return "", err
+		}
+		resourceVersion = listVersion
+		pw.markActivity()
+	}
+
+	timeout := pw.watchTimeout()
+
+	// The server ends the watch after timeout; the client-side deadline catches dead connections
+	ctx, cancel := context.WithTimeout(pw.ctx, timeout+30*time.Second)
+	defer cancel()
+
+	podWatch, err := pw.k8sClient.WatchPods(ctx, pw.namespace, resourceVersion, timeout)
+	if err != nil {
+		return "", err
+	}
+	defer podWatch.Stop()
+
+	pw.markActivity()
 
 	for {
 		select {
 		case <-pw.stopCh:
+			return resourceVersion, nil
+		case event, ok := <-podWatch.ResultChan():
+			if !ok {
+				// Watch expired or the connection dropped - re-establish it
+				return resourceVersion, nil
+			}
+			if err := pw.handleWatchEvent(event); err != nil {
+				return "", err
+			}
+			if pod, ok := event.Object.(*v1.Pod); ok && pod.ResourceVersion != "" {
+				resourceVersion = pod.ResourceVersion
+			}
+			if pw.watchGeneration.Load() != generation {
+				return "", errWatchSuperseded
+			}
+			pw.markActivity()
+		}
+	}
+}
+
+// handleWatchEvent processes a single pod watch event
+func (pw *PodWatcher) handleWatchEvent(event watch.Event) error {
+	switch event.Type {
+	case watch.Added, watch.Modified:
+		pod, ok := event.Object.(*v1.Pod)
+		if !ok {
 			return nil
-		case <-ticker.C:
-			if err := pw.scanPods(); err != nil {
-				log.Printf("❌ Scan error: %v", err)
+		}
+		if pw.shouldProcessPod(pod) {
+			if pw.fixQueue != nil {
+				pw.fixQueue.enqueue(pw, pod)
+				return nil
 			}
+			// Reflexion can outlast the stall timeout; that is progress, not a wedged watch
+			pw.processing.Add(1)
+			pw.processPod(pod)
+			pw.processing.Add(-1)
+		} else if pw.isStuckTerminating(pod) {
+			pw.handleStuckTerminating(pod)
 		}
+	case watch.Error:
+		return fmt.Errorf("watch stream error: %w", apierrors.FromObject(event.Object))
 	}
+	return nil
 }
 
-// scanPods scans all pods in the namespace
-func (pw *PodWatcher) scanPods() error {
-	pods, err := pw.k8sClient.ListPods(pw.namespace)
+// scanPods scans all pods in the namespace and returns the resource version of the list
+func (pw *PodWatcher) scanPods() (string, error) {
+	pods, err := pw.k8sClient.ListPods(pw.ctx, pw.namespace)
 	if err != nil {
-		return fmt.Errorf("failed to list pods: %w", err)
+		return "", fmt.Errorf("failed to list pods: %w", err)
 	}
 
-	log.Printf("🔍 Scanning %d pods in namespace %s", len(pods.Items), pw.namespace)
+	pw.logger.Printf("🔍 Scanning %d pods in namespace %s", len(pods.Items), pw.namespace)
 
-	for _, pod := range pods.Items {
-		if pw.shouldProcessPod(&pod) {
-			pw.processPod(&pod)
+	var failedPods, stuckPods []*v1.Pod
+	for i := range pods.Items {
+		if pw.shouldProcessPod(&pods.Items[i]) {
+			failedPods = append(failedPods, &pods.Items[i])
+		} else if pw.isStuckTerminating(&pods.Items[i]) {
+			stuckPods = append(stuckPods, &pods.Items[i])
 		}
 	}
 
-	return nil
+	if pw.fixQueue != nil {
+		// The cluster's fix queue orders failures across all of its namespaces
+		for _, pod := range failedPods {
+			pw.fixQueue.enqueue(pw, pod)
+		}
+	} else {
+		// Handle the most severe failures first
+		prioritized := pw.prioritizePods(failedPods)
+		for i, pod := range prioritized {
+			pw.metrics.setQueueDepth(pw.cluster, len(prioritized)-i)
+			pw.processPod(pod)
+		}
+		pw.metrics.setQueueDepth(pw.cluster, 0)
+	}
+
+	// Pods held in Terminating by finalizers
+	for _, pod := range stuckPods {
+		pw.handleStuckTerminating(pod)
+	}
+
+	return pods.ResourceVersion, nil
 }
 
 // shouldProcessPod determines if a pod should be processed
 func (pw *PodWatcher) shouldProcessPod(pod *v1.Pod) bool {
+	// Skip completed pods (e.g. finished Jobs) - there is nothing to fix
+	if pod.Status.Phase == v1.PodSucceeded {
+		return false
+	}
+
+	// Skip pods that are already being deleted
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+
 	// Use UID for unique pod identification (handles recreated pods with same name)
 	podKey := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, pod.UID)
 
 	// Debug: Log pod status
-	log.Printf("🔍 DEBUG: Pod %s (UID: %s) - Phase: %s, ContainerStatuses: %d",
+	pw.logger.Printf("🔍 DEBUG: Pod %s (UID: %s) - Phase: %s, ContainerStatuses: %d",
 		pod.Name, string(pod.UID)[:8], pod.Status.Phase, len(pod.Status.ContainerStatuses))
 
 	for i, containerStatus := range pod.Status.ContainerStatuses {
-		log.Printf("🔍 DEBUG: Container %d - Ready: %t, State: %+v",
+		pw.logger.Printf("🔍 DEBUG: Container %d - Ready: %t, State: %+v",
 			i, containerStatus.Ready, containerStatus.State)
 		if containerStatus.State.Waiting != nil {
-			log.Printf("🔍 DEBUG: Waiting reason: %s, message: %s",
+			pw.logger.Printf("🔍 DEBUG: Waiting reason: %s, message: %s",
 				containerStatus.State.Waiting.Reason, containerStatus.State.Waiting.Message)
 		}
 	}
 
 	// Check if pod has failed
 	isFailed := pw.k8sClient.IsPodFailed(pod)
-	log.Printf("🔍 DEBUG: Pod %s IsPodFailed result: %t", pod.Name, isFailed)
+	pw.logger.Printf("🔍 DEBUG: Pod %s IsPodFailed result: %t", pod.Name, isFailed)
 
 	if !isFailed {
 		return false
@@ -147,68 +353,161 @@ func (pw *PodWatcher) shouldProcessPod(pod *v1.Pod) bool {
 	processed := pw.processedPods[podKey]
 	pw.mutex.RUnlock()
 
-	log.Printf("🔍 DEBUG: Pod %s (UID: %s) already processed: %t", pod.Name, string(pod.UID)[:8], processed)
+	pw.logger.Printf("🔍 DEBUG: Pod %s (UID: %s) already processed: %t", pod.Name, string(pod.UID)[:8], processed)
 	return !processed
 }
 
+// isProcessed reports whether this instance of the pod has already been processed
+func (pw *PodWatcher) isProcessed(pod *v1.Pod) bool {
+	pw.mutex.RLock()
+	defer pw.mutex.RUnlock()
+	return pw.processedPods[fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, pod.UID)]
+}
+
 // processPod processes a failed pod
 func (pw *PodWatcher) processPod(pod *v1.Pod) {
 	// Use UID for unique identification (same as shouldProcessPod)
 	podKey := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, pod.UID)
 	errorType := pw.k8sClient.GetPodErrorType(pod)
 
-	log.Printf("🚨 Processing failed pod: %s/%s (UID: %s), Error: %s",
+	// While paused, keep detecting but leave the pod for after Resume
+	if pw.deferWhilePaused(podKey, errorType) {
+		return
+	}
+
+	pw.logger.Printf("🚨 Processing failed pod: %s/%s (UID: %s), Error: %s",
 		pod.Namespace, pod.Name, string(pod.UID)[:8], errorType)
 
 	// Mark as processed (by UID)
 	pw.mutex.Lock()
 	pw.processedPods[podKey] = true
 	pw.mutex.Unlock()
+	pw.metrics.errorDetected(pw.cluster, errorType)
+
+	// Evicted pods are cleaned up, not fixed
+	if errorType == "Evicted" {
+		pw.handleEvicted(pod)
+		return
+	}
+
+	// A failing canary is doing its job - leave it to the rollout controller. The check
+	// looks up the pod's rollout, so it is skipped when canaries are fixed anyway.
+	if !pw.fixCanaries {
+		if reason := pw.canaryReason(pod); reason != "" {
+			pw.logger.Printf("🐤 Skipping pod %s/%s: %s (use --fix-canaries to override)", pod.Namespace, pod.Name, reason)
+			return
+		}
+	}
+
+	// Turn the cryptic runtime error into an actionable message
+	if errorType == "NoCommandSpecified" {
+		if containerStatus, found := k8s.FindNoCommandContainer(pod); found {
+			pw.logger.Printf("🧱 Image %s has no entrypoint; specify spec.containers[].command for container %s",
+				containerStatus.Image, containerStatus.Name)
+		}
+	}
+
+	// Tell invalid resource settings apart from missing ConfigMaps/Secrets; a missing
+	// reference needs a human, not a generated fix
+	switch errorType {
+	case "CreateContainerConfigError", "CreateContainerError", "ConfigError":
+		if pw.diagnoseMissingReference(pod, errorType) {
+			return
+		}
+		pw.diagnoseResourceConfig(pod)
+	}
+
+	// Correlate OOMKilled pods by node to catch node-level memory pressure
+	if errorType == "OOMKilled" {
+		pw.correlateOOMKill(pod)
+	}
 
 	// Get additional data
-	events, err := pw.k8sClient.GetPodEvents(pod.Namespace, pod.Name)
+	events, err := pw.k8sClient.GetPodEvents(pw.ctx, pod.Namespace, pod.Name)
 	if err != nil {
-		log.Printf("❌ Failed to get events for pod %s: %v", podKey, err)
+		pw.logger.Printf("❌ Failed to get events for pod %s: %v", podKey, err)
 		events = []v1.Event{}
 	}
 
-	logs, err := pw.k8sClient.GetPodLogs(pod.Namespace, pod.Name)
-	if err != nil {
-		log.Printf("❌ Failed to get logs for pod %s: %v", podKey, err)
-		logs = []string{"Failed to retrieve logs"}
+	logs := pw.containerLogs(pod, errorType)
+
+	// Point at rollouts or config changes that preceded the failure
+	recentChanges := pw.correlateRecentChanges(pod)
+
+	// Stop auto-fixing workloads that keep breaking the same way after fixes
+	if pw.checkOscillation(pod, errorType) {
+		return
+	}
+
+	// Pods that break again after an agent fix are only re-analyzed unless --refix is set
+	if pw.handleRecurrence(pod, events, errorType) {
+		return
+	}
+
+	// Bare pods may be deleted or skipped instead of fixed
+	if pw.applyBarePodPolicy(pod, errorType) {
+		return
+	}
+
+	// Offline mode: diagnose with built-in heuristics only
+	if pw.offline {
+		pw.reportHeuristicDiagnosis(pod, events, errorType)
+		for _, hint := range recentChanges {
+			pw.logger.Printf("   💡 Recommendation: review or roll back the change - %s", hint)
+		}
+		return
 	}
 
 	// Send to reflexion service
-	log.Printf("📡 Sending to reflexion service...")
-	response, err := pw.reflexionClient.ProcessPodError(pod, events, logs, errorType)
+	pw.logger.Printf("📡 Sending to reflexion service...")
+	fixDone := pw.metrics.fixStarted(pw.cluster)
+	response, err := pw.reflexionClient.ProcessPodError(pod, events, logs, errorType, recentChanges)
+	fixDone()
+	pw.metrics.setCircuitOpen(pw.cluster, pw.reflexionClient.CircuitOpen())
+	if errors.Is(err, reflexion.ErrCircuitOpen) {
+		// Keep the pipeline responsive during an outage with the built-in diagnosis
+		pw.logger.Printf("⚡ Reflexion service unavailable (circuit breaker open), falling back to offline diagnosis")
+		pw.reportHeuristicDiagnosis(pod, events, errorType)
+		return
+	}
 	if err != nil {
-		log.Printf("❌ Failed to process pod with reflexion: %v", err)
+		pw.logger.Printf("❌ Failed to process pod with reflexion: %v", err)
+		record := pw.newFixRecord(pod, errorType, nil)
+		record.Message = err.Error()
+		pw.recordFix(record)
 		return
 	}
-	log.Printf("✅ Response received from reflexion service")
+	pw.logger.Printf("✅ Response received from reflexion service")
 
 	// Log the response
-	log.Printf("✅ Reflexion completed for pod %s:", podKey)
-	log.Printf("   📋 Workflow ID: %s", response.WorkflowID)
-	log.Printf("   🎯 Strategy: %v", response.FinalStrategy["type"])
-	log.Printf("   📊 Confidence: %v", response.FinalStrategy["confidence"])
-	log.Printf("   ⏱️  Resolution Time: %.2fs", response.ResolutionTime)
-	log.Printf("   🔍 Used Real K8s Data: %v", response.ReflexionSummary["used_real_k8s_data"])
+	pw.logger.Printf("✅ Reflexion completed for pod %s:", podKey)
+	pw.logger.Printf("   📋 Workflow ID: %s", response.WorkflowID)
+	pw.logger.Printf("   🎯 Strategy: %v", response.FinalStrategy["type"])
+	pw.logger.Printf("   📊 Confidence: %v", response.FinalStrategy["confidence"])
+	pw.logger.Printf("   ⏱️  Resolution Time: %.2fs", response.ResolutionTime)
+	pw.logger.Printf("   🔍 Used Real K8s Data: %v", response.ReflexionSummary["used_real_k8s_data"])
 
 	if response.RequiresHumanIntervention {
-		log.Printf("🚨 Human intervention required for pod %s", podKey)
+		pw.logger.Printf("🚨 Human intervention required for pod %s", podKey)
 	} else {
-		log.Printf("🤖 AI strategy available for pod %s", podKey)
-		log.Printf("📄 YAML Manifest mode active - Python service handles pod fixing automatically")
-
-		// YAML mode: Python service already processed the pod with YAML manifests
-		// No need for separate kubectl command generation
+		pw.logger.Printf("🤖 AI strategy available for pod %s", podKey)
+		if pw.executeURL != "" {
+			// Command mode: generate kubectl commands and run them through the execution server
+			if err := pw.generateAndExecuteCommands(pod, response, errorType); err != nil {
+				pw.logger.Printf("❌ Failed to generate/execute commands for pod %s: %v", podKey, err)
+			}
+		} else {
+			// YAML mode: Python service already processed the pod with YAML manifests
+			pw.logger.Printf("📄 YAML Manifest mode active - Python service handles pod fixing automatically")
+		}
 	}
+
+	pw.recordFix(pw.newFixRecord(pod, errorType, response))
 }
 
 // periodicScan performs periodic full scans
 func (pw *PodWatcher) periodicScan() {
-	ticker := time.NewTicker(60 * time.Second) // Full scan every minute
+	ticker := time.NewTicker(pw.fullScanEvery)
 	defer ticker.Stop()
 
 	for {
@@ -216,9 +515,9 @@ func (pw *PodWatcher) periodicScan() {
 		case <-pw.stopCh:
 			return
 		case <-ticker.C:
-			log.Printf("🔄 Performing periodic full scan...")
-			if err := pw.scanPods(); err != nil {
-				log.Printf("❌ Periodic scan error: %v", err)
+			pw.logger.Printf("🔄 Performing periodic full scan...")
+			if _, err := pw.scanPods(); err != nil {
+				pw.logger.Printf("❌ Periodic scan error: %v", err)
 			}
 		}
 	}
@@ -242,12 +541,12 @@ func (pw *PodWatcher) ResetProcessedPods() {
 	defer pw.mutex.Unlock()
 
 	pw.processedPods = make(map[string]bool)
-	log.Printf("🔄 Processed pods list reset")
+	pw.logger.Printf("🔄 Processed pods list reset")
 }
 
 // generateAndExecuteCommands generates kubectl commands using AI and executes them
 func (pw *PodWatcher) generateAndExecuteCommands(pod *v1.Pod, response *reflexion.ProcessPodErrorResponse, errorType string) error {
-	log.Printf("🔧 Generating kubectl commands for pod %s", pod.Name)
+	pw.logger.Printf("🔧 Generating kubectl commands for pod %s", pod.Name)
 
 	// Step 1: Call Python service to generate commands
 	commands, err := pw.generateCommands(pod, response, errorType)
@@ -255,7 +554,7 @@ func (pw *PodWatcher) generateAndExecuteCommands(pod *v1.Pod, response *reflexio
 		return fmt.Errorf("failed to generate commands: %v", err)
 	}
 
-	log.Printf("✅ Generated %d command categories", len(commands))
+	pw.logger.Printf("✅ Generated %d command categories", len(commands))
 
 	// Step 2: Execute commands via local HTTP server
 	executionResult, err := pw.executeCommands(pod, commands, errorType)
@@ -263,24 +562,24 @@ func (pw *PodWatcher) generateAndExecuteCommands(pod *v1.Pod, response *reflexio
 		return fmt.Errorf("failed to execute commands: %v", err)
 	}
 
-	log.Printf("📊 Execution result: %s (%d/%d commands succeeded)",
+	pw.logger.Printf("📊 Execution result: %s (%d/%d commands succeeded)",
 		executionResult.Status, executionResult.SuccessCount, executionResult.TotalCommands)
 
 	// Step 3: Send execution feedback to Python service for reflexion
 	err = pw.sendExecutionFeedback(pod, response, executionResult, errorType)
 	if err != nil {
-		log.Printf("⚠️  Failed to send execution feedback: %v", err)
+		pw.logger.Printf("⚠️  Failed to send execution feedback: %v", err)
 		// Continue anyway, don't fail the whole process
 	}
 
 	// Step 4: If pod was successfully fixed, remove from processed list
 	// This allows re-processing if the same pod fails again
 	if executionResult.Status == "success" {
-		podKey := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+		podKey := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, pod.UID)
 		pw.mutex.Lock()
 		delete(pw.processedPods, podKey)
 		pw.mutex.Unlock()
-		log.Printf("✅ Pod %s successfully fixed, removed from processed list", podKey)
+		pw.logger.Printf("✅ Pod %s successfully fixed, removed from processed list", podKey)
 	}
 
 	return nil
@@ -321,7 +620,7 @@ func (pw *PodWatcher) generateCommands(pod *v1.Pod, response *reflexion.ProcessP
 	}
 
 	// Make HTTP request to Python service
-	pythonURL := "http://localhost:8000/api/v1/executor/generate-commands"
+	pythonURL := pw.reflexionClient.BaseURL() + "/api/v1/executor/generate-commands"
 	resp, err := http.Post(pythonURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to call Python service: %v", err)
@@ -363,8 +662,7 @@ func (pw *PodWatcher) executeCommands(pod *v1.Pod, commands map[string][]string,
 	}
 
 	// Make HTTP request to local Go server
-	goURL := "http://localhost:8080/api/v1/execute-commands"
-	resp, err := http.Post(goURL, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := http.Post(pw.executeURL, "application/json", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to call Go HTTP server: %v", err)
 	}
@@ -409,7 +707,7 @@ type CommandResult struct {
 
 // sendExecutionFeedback sends execution results back to Python service for reflexion
 func (pw *PodWatcher) sendExecutionFeedback(pod *v1.Pod, response *reflexion.ProcessPodErrorResponse, executionResult *ExecutionResult, errorType string) error {
-	log.Printf("🔄 Sending execution feedback for reflexion learning...")
+	pw.logger.Printf("🔄 Sending execution feedback for reflexion learning...")
 
 	// Prepare feedback data
 	feedbackData := map[string]interface{}{
@@ -431,24 +729,18 @@ func (pw *PodWatcher) sendExecutionFeedback(pod *v1.Pod, response *reflexion.Pro
 		"timestamp": time.Now().Format(time.RFC3339),
 	}
 
-	// Convert to JSON
-	jsonData, err := json.Marshal(feedbackData)
-	if err != nil {
-		return fmt.Errorf("failed to marshal feedback: %v", err)
+	// Queue for the next batch when batching is enabled
+	if pw.feedback != nil {
+		pw.feedback.add(feedbackData)
+		pw.logger.Printf("📥 Execution feedback queued for the next batch")
+		return nil
 	}
 
 	// Send to Python service reflexion endpoint
-	pythonURL := "http://localhost:8000/api/v1/reflexion/execution-feedback"
-	resp, err := http.Post(pythonURL, "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to send feedback to Python service: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Python service returned status %d for feedback", resp.StatusCode)
+	if _, err := postFeedback(pw.reflexionClient.BaseURL()+feedbackPath, feedbackData); err != nil {
+		return err
 	}
 
-	log.Printf("✅ Execution feedback sent for reflexion learning")
+	pw.logger.Printf("✅ Execution feedback sent for reflexion learning")
 	return nil
 }
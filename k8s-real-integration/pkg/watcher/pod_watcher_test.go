@@ -0,0 +1,170 @@
+package watcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s-real-integration-go/pkg/k8s"
+	"k8s-real-integration-go/pkg/reflexion"
+)
+
+// stubServices stands in for the reflexion service and the execute-commands endpoint
+type stubServices struct {
+	mutex    sync.Mutex
+	calls    map[string]int
+	feedback []map[string]interface{}
+}
+
+func (s *stubServices) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.calls[r.URL.Path]++
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/api/v1/reflexion/process-with-k8s-data":
+		json.NewEncoder(w).Encode(reflexion.ReflexionResponse{
+			WorkflowID:    "wf-1",
+			Success:       true,
+			FinalStrategy: map[string]interface{}{"type": "recreate", "confidence": 0.9},
+		})
+	case "/api/v1/executor/generate-commands":
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"commands": map[string][]string{"fix_commands": {"kubectl delete pod web-1 -n default"}},
+		})
+	case "/api/v1/execute-commands":
+		json.NewEncoder(w).Encode(ExecutionResult{Status: "success", TotalCommands: 1, SuccessCount: 1})
+	case feedbackPath:
+		var item map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&item)
+		s.feedback = append(s.feedback, item)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *stubServices) count(path string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls[path]
+}
+
+// crashingPod returns a pod of Deployment web that is in CrashLoopBackOff
+func crashingPod(name, uid string) *v1.Pod {
+	controller := true
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			UID:               types.UID(uid),
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-abc", Controller: &controller,
+			}},
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "web:1"}}},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:         "app",
+				RestartCount: 5,
+				State:        v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}},
+		},
+	}
+}
+
+// newTestWatcher returns a watcher over a fake cluster holding pod, talking to stub services
+func newTestWatcher(t *testing.T, pod *v1.Pod) (*PodWatcher, *stubServices) {
+	t.Helper()
+	controller := true
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name: "web-abc", Namespace: "default",
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: &controller}},
+	}}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+
+	services := &stubServices{calls: make(map[string]int)}
+	ts := httptest.NewServer(services)
+	t.Cleanup(ts.Close)
+
+	client := k8s.NewClientFromClientset(fake.NewSimpleClientset(pod, replicaSet, deployment))
+	pw := NewPodWatcher(client, reflexion.NewClientWithOptions(ts.URL, reflexion.WithMaxAttempts(1)), "default")
+	t.Cleanup(pw.cancel)
+	return pw, services
+}
+
+func podKey(pod *v1.Pod) string {
+	return fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, pod.UID)
+}
+
+func TestShouldProcessPodKeysByUID(t *testing.T) {
+	pod := crashingPod("web-1", "uid-00000001")
+	pw, _ := newTestWatcher(t, pod)
+
+	if !pw.shouldProcessPod(pod) {
+		t.Fatal("a failed pod should be processed")
+	}
+	pw.processedPods[podKey(pod)] = true
+	if pw.shouldProcessPod(pod) {
+		t.Error("the same pod instance should not be processed twice")
+	}
+
+	recreated := crashingPod("web-1", "uid-00000002")
+	if !pw.shouldProcessPod(recreated) {
+		t.Error("a recreated pod with the same name but a new UID should be processed")
+	}
+}
+
+func TestProcessPodYAMLModeDoesNotGenerateCommands(t *testing.T) {
+	pod := crashingPod("web-1", "uid-00000001")
+	pw, services := newTestWatcher(t, pod)
+
+	pw.processPod(pod)
+
+	if n := services.count("/api/v1/reflexion/process-with-k8s-data"); n != 1 {
+		t.Fatalf("reflexion service called %d times, want 1", n)
+	}
+	if n := services.count("/api/v1/executor/generate-commands"); n != 0 {
+		t.Errorf("YAML mode generated commands %d times", n)
+	}
+	if !pw.processedPods[podKey(pod)] {
+		t.Error("the pod should stay marked as processed by UID")
+	}
+}
+
+func TestProcessPodCommandMode(t *testing.T) {
+	pod := crashingPod("web-1", "uid-00000001")
+	pw, services := newTestWatcher(t, pod)
+	pw.SetCommandExecution(pw.reflexionClient.BaseURL() + "/api/v1/execute-commands")
+
+	pw.processPod(pod)
+
+	for _, path := range []string{
+		"/api/v1/reflexion/process-with-k8s-data",
+		"/api/v1/executor/generate-commands",
+		"/api/v1/execute-commands",
+		feedbackPath,
+	} {
+		if n := services.count(path); n != 1 {
+			t.Errorf("%s called %d times, want 1", path, n)
+		}
+	}
+	if len(services.feedback) == 1 && services.feedback[0]["workflow_id"] != "wf-1" {
+		t.Errorf("feedback workflow_id = %v, want wf-1", services.feedback[0]["workflow_id"])
+	}
+	if pw.processedPods[podKey(pod)] {
+		t.Error("a successfully fixed pod should be removed from the processed list by its UID key")
+	}
+}
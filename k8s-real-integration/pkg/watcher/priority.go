@@ -0,0 +1,84 @@
+package watcher
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// errorSeverity is the base urgency of each error type; unknown types score 1
+var errorSeverity = map[string]int{
+	"OOMKilled":                     50,
+	"CrashLoopBackOff":              40,
+	"Segfault":                      40,
+	"SIGTERM":                       30,
+	"NoCommandSpecified":            30,
+	"RunContainerError":             30,
+	"ContainerCannotRun":            30,
+	"CreateContainerConfigError":    25,
+	"CreateContainerError":          25,
+	"ConfigError":                   25,
+	"InitContainerFailed":           20,
+	"ImagePullBackOff":              15,
+	"InvalidImageName":              15,
+	"InitContainerImagePullBackOff": 15,
+	"InitContainerInvalidImageName": 15,
+	"PodFailed":                     10,
+	"PodPending":                    5,
+	"Evicted":                       2,
+}
+
+// prioritizedPod is a failed pod together with its urgency score
+type prioritizedPod struct {
+	pod   *v1.Pod
+	score int
+}
+
+// severityScore computes how urgently a failed pod should be handled. Rapid restarts and
+// failures affecting several replicas of the same workload rank higher.
+func severityScore(errorType string, pod *v1.Pod, failingReplicas int) int {
+	score, ok := errorSeverity[errorType]
+	if !ok {
+		score = 1
+	}
+
+	var restarts int32
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		restarts += containerStatus.RestartCount
+	}
+	if restarts > 20 {
+		restarts = 20
+	}
+
+	return score + int(restarts) + 10*(failingReplicas-1)
+}
+
+// prioritizePods orders failed pods so the most impactful problems are handled first
+func (pw *PodWatcher) prioritizePods(pods []*v1.Pod) []*v1.Pod {
+	// Count failing replicas per workload
+	workloads := make([]string, len(pods))
+	replicas := make(map[string]int)
+	for i, pod := range pods {
+		workloads[i] = pw.workloadKey(pod)
+		replicas[workloads[i]]++
+	}
+
+	prioritized := make([]prioritizedPod, 0, len(pods))
+	for i, pod := range pods {
+		errorType := pw.k8sClient.GetPodErrorType(pod)
+		prioritized = append(prioritized, prioritizedPod{
+			pod:   pod,
+			score: severityScore(errorType, pod, replicas[workloads[i]]),
+		})
+	}
+
+	sort.SliceStable(prioritized, func(i, j int) bool {
+		return prioritized[i].score > prioritized[j].score
+	})
+
+	ordered := make([]*v1.Pod, 0, len(prioritized))
+	for _, p := range prioritized {
+		ordered = append(ordered, p.pod)
+	}
+	return ordered
+}
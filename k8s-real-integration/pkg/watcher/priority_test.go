@@ -0,0 +1,54 @@
+package watcher
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s-real-integration-go/pkg/k8s"
+	"k8s-real-integration-go/pkg/reflexion"
+)
+
+func TestPerformWatchHandlesExistingFailuresInPriorityOrder(t *testing.T) {
+	imagePull := crashingPod("a-image", "uid-00000001")
+	imagePull.Status.ContainerStatuses[0].RestartCount = 0
+	imagePull.Status.ContainerStatuses[0].State = v1.ContainerState{
+		Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+	}
+	crashing := crashingPod("b-crash", "uid-00000002")
+
+	services := &stubServices{calls: make(map[string]int)}
+	ts := httptest.NewServer(services)
+	defer ts.Close()
+
+	client := k8s.NewClientFromClientset(fake.NewSimpleClientset(imagePull, crashing))
+	pw := NewPodWatcher(client, reflexion.NewClientWithOptions(ts.URL, reflexion.WithMaxAttempts(1)), "default")
+	defer pw.cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := pw.performWatch(0, "")
+		done <- err
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(pw.GetRecentFixes()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(pw.stopCh)
+	if err := <-done; err != nil {
+		t.Fatalf("performWatch: %v", err)
+	}
+
+	// Recent fixes are newest first, so the most severe failure is last
+	fixes := pw.GetRecentFixes()
+	if len(fixes) != 2 {
+		t.Fatalf("expected both existing failures to be handled, got %d", len(fixes))
+	}
+	if fixes[1].PodName != "b-crash" || fixes[0].PodName != "a-image" {
+		t.Errorf("handled %s before %s, want the CrashLoopBackOff pod first", fixes[1].PodName, fixes[0].PodName)
+	}
+}
@@ -0,0 +1,95 @@
+package watcher
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// minLivenessInitialDelay is the initialDelaySeconds below which a liveness probe is suspected
+// of killing a slow-starting container
+const minLivenessInitialDelay = 30
+
+// minLivenessTimeout is the smallest timeoutSeconds suggested for a tuned liveness probe
+const minLivenessTimeout = 5
+
+// sigtermExitCode is the exit code of a container stopped by SIGTERM, which is how the
+// kubelet stops a container whose liveness probe failed
+const sigtermExitCode = 143
+
+// probeTuning is a suggested liveness probe change for one container
+type probeTuning struct {
+	Container            string
+	InitialDelayBefore   int32
+	InitialDelayAfter    int32
+	TimeoutSecondsBefore int32
+	TimeoutSecondsAfter  int32
+}
+
+// livenessProbeTuning returns a liveness probe change for the failing container when it was
+// last stopped with SIGTERM and its existing liveness probe starts checking too early
+func livenessProbeTuning(pod *v1.Pod) (probeTuning, bool) {
+	name := failingContainer(pod)
+	if name == "" && len(pod.Spec.Containers) > 0 {
+		name = pod.Spec.Containers[0].Name
+	}
+
+	var probe *v1.Probe
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			probe = container.LivenessProbe
+			break
+		}
+	}
+	if probe == nil || probe.InitialDelaySeconds >= minLivenessInitialDelay {
+		return probeTuning{}, false
+	}
+
+	killed := false
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != name {
+			continue
+		}
+		for _, terminated := range []*v1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+			if terminated != nil && terminated.ExitCode == sigtermExitCode {
+				killed = true
+			}
+		}
+	}
+	if !killed {
+		return probeTuning{}, false
+	}
+
+	// The API server defaults an unset timeout to one second
+	timeout := probe.TimeoutSeconds
+	if timeout <= 0 {
+		timeout = 1
+	}
+	return probeTuning{
+		Container:            name,
+		InitialDelayBefore:   probe.InitialDelaySeconds,
+		InitialDelayAfter:    max(2*probe.InitialDelaySeconds, minLivenessInitialDelay),
+		TimeoutSecondsBefore: timeout,
+		TimeoutSecondsAfter:  max(2*timeout, minLivenessTimeout),
+	}, true
+}
+
+// diagnosePod returns the built-in diagnosis for a failed pod, replacing the generic crash
+// diagnosis when an aggressive liveness probe is the likely cause
+func diagnosePod(pod *v1.Pod, errorType string) heuristicDiagnosis {
+	diagnosis := diagnose(errorType)
+	if errorType != "CrashLoopBackOff" && errorType != "SIGTERM" {
+		return diagnosis
+	}
+
+	tuning, ok := livenessProbeTuning(pod)
+	if !ok {
+		return diagnosis
+	}
+	return heuristicDiagnosis{
+		Cause: fmt.Sprintf("Liveness probe of container %q starts after %ds with a %ds timeout and kills the container (exit code %d) before it has started",
+			tuning.Container, tuning.InitialDelayBefore, tuning.TimeoutSecondsBefore, sigtermExitCode),
+		Action: fmt.Sprintf("Raise the liveness probe initialDelaySeconds from %d to %d and timeoutSeconds from %d to %d in the workload's pod template, or add a startupProbe",
+			tuning.InitialDelayBefore, tuning.InitialDelayAfter, tuning.TimeoutSecondsBefore, tuning.TimeoutSecondsAfter),
+	}
+}
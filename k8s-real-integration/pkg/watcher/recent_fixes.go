@@ -0,0 +1,145 @@
+package watcher
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"k8s-real-integration-go/pkg/publisher"
+	"k8s-real-integration-go/pkg/reflexion"
+)
+
+// defaultRecentFixesSize is the number of fix records kept when no size is configured
+const defaultRecentFixesSize = 100
+
+// FixRecord describes the outcome of one fix attempt for a failed pod. Informational records
+// describe an observation instead, such as a missing reference that has since been created.
+type FixRecord struct {
+	Timestamp                 time.Time `json:"timestamp"`
+	Cluster                   string    `json:"cluster,omitempty"`
+	Namespace                 string    `json:"namespace"`
+	PodName                   string    `json:"pod_name"`
+	PodUID                    string    `json:"pod_uid"`
+	ErrorType                 string    `json:"error_type"`
+	WorkflowID                string    `json:"workflow_id,omitempty"`
+	Strategy                  string    `json:"strategy,omitempty"`
+	Confidence                float64   `json:"confidence"`
+	Success                   bool      `json:"success"`
+	RequiresHumanIntervention bool      `json:"requires_human_intervention"`
+	Message                   string    `json:"message,omitempty"`
+	Recurrence                bool      `json:"recurrence,omitempty"`
+	Informational             bool      `json:"informational,omitempty"`
+}
+
+// fixRing is a fixed-size, concurrency-safe ring buffer of fix records
+type fixRing struct {
+	records []FixRecord
+	next    int
+	full    bool
+	mutex   sync.RWMutex
+}
+
+// newFixRing creates a ring buffer holding up to size records
+func newFixRing(size int) *fixRing {
+	if size <= 0 {
+		size = defaultRecentFixesSize
+	}
+	return &fixRing{records: make([]FixRecord, size)}
+}
+
+// add stores a record, overwriting the oldest one when full
+func (r *fixRing) add(record FixRecord) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.records[r.next] = record
+	r.next = (r.next + 1) % len(r.records)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// list returns the stored records, newest first
+func (r *fixRing) list() []FixRecord {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	count := r.next
+	if r.full {
+		count = len(r.records)
+	}
+
+	result := make([]FixRecord, 0, count)
+	for i := 1; i <= count; i++ {
+		idx := (r.next - i + len(r.records)) % len(r.records)
+		result = append(result, r.records[idx])
+	}
+	return result
+}
+
+// SetRecentFixesSize sets how many recent fix records are kept in memory
+func (pw *PodWatcher) SetRecentFixesSize(size int) {
+	pw.recentFixes = newFixRing(size)
+}
+
+// GetRecentFixes returns the most recent fix records, newest first
+func (pw *PodWatcher) GetRecentFixes() []FixRecord {
+	return pw.recentFixes.list()
+}
+
+// newFixRecord builds a fix record from a reflexion response (which may be nil on failure)
+func (pw *PodWatcher) newFixRecord(pod *v1.Pod, errorType string, response *reflexion.ReflexionResponse) FixRecord {
+	record := FixRecord{
+		Timestamp: time.Now(),
+		Cluster:   pw.cluster,
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+		PodUID:    string(pod.UID),
+		ErrorType: errorType,
+	}
+	_, record.Recurrence = fixedByAgent(pod)
+
+	if response != nil {
+		record.WorkflowID = response.WorkflowID
+		record.Success = response.Success
+		record.RequiresHumanIntervention = response.RequiresHumanIntervention
+		if strategy, ok := response.FinalStrategy["type"]; ok {
+			record.Strategy = fmt.Sprintf("%v", strategy)
+		}
+		if confidence, ok := response.FinalStrategy["confidence"].(float64); ok {
+			record.Confidence = confidence
+		}
+	}
+
+	return record
+}
+
+// SetPublisher sets a publisher that receives every fix record
+func (pw *PodWatcher) SetPublisher(p publisher.Publisher) {
+	pw.publisher = p
+}
+
+// recordFix stores the outcome of a fix attempt and forwards it to the configured sinks
+func (pw *PodWatcher) recordFix(record FixRecord) {
+	pw.recentFixes.add(record)
+	pw.auditFix(record)
+
+	// Observations are shown with the recent fixes but are neither counted nor announced
+	if record.Informational {
+		return
+	}
+
+	pw.sessionCounts.record(record)
+	pw.metrics.fixRecorded(record)
+
+	if pw.publisher != nil {
+		if err := pw.publisher.Publish(record); err != nil {
+			pw.logger.Printf("⚠️  Failed to publish fix result for pod %s/%s: %v", record.Namespace, record.PodName, err)
+		}
+	}
+
+	pw.runHooks(record)
+	pw.notify(record)
+}
@@ -0,0 +1,77 @@
+package watcher
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Markers the reflexion service stamps on pods it recreated
+const (
+	fixedByMarker      = "fixed-by"
+	fixedByValue       = "reflexion-system"
+	fixTimestampMarker = "fix-timestamp"
+	fixTimestampLayout = "20060102-150405"
+)
+
+// recentFixWindow is how long after an agent fix a new failure counts as a recurrence
+const recentFixWindow = 24 * time.Hour
+
+// SetRefix allows automatically fixing again pods that break after an agent fix
+func (pw *PodWatcher) SetRefix(refix bool) {
+	pw.refix = refix
+}
+
+// marker returns a fix marker from the pod's labels or annotations
+func marker(pod *v1.Pod, key string) (string, bool) {
+	if value, ok := pod.Labels[key]; ok {
+		return value, true
+	}
+	value, ok := pod.Annotations[key]
+	return value, ok
+}
+
+// fixedByAgent reports whether the pod was recently recreated by the reflexion service
+// and when. An unparsable fix timestamp is treated as recent.
+func fixedByAgent(pod *v1.Pod) (time.Time, bool) {
+	if value, ok := marker(pod, fixedByMarker); !ok || value != fixedByValue {
+		return time.Time{}, false
+	}
+
+	value, _ := marker(pod, fixTimestampMarker)
+	fixedAt, err := time.ParseInLocation(fixTimestampLayout, value, time.Local)
+	if err != nil {
+		return time.Time{}, true
+	}
+	return fixedAt, time.Since(fixedAt) <= recentFixWindow
+}
+
+// handleRecurrence reports a pod that broke again after an agent fix and reports whether
+// processing should stop. Without --refix the pod is diagnosed but not fixed again.
+func (pw *PodWatcher) handleRecurrence(pod *v1.Pod, events []v1.Event, errorType string) bool {
+	fixedAt, recurred := fixedByAgent(pod)
+	if !recurred {
+		return false
+	}
+
+	since := "an earlier agent fix"
+	if !fixedAt.IsZero() {
+		since = "agent fix at " + fixedAt.Format(time.RFC3339)
+	}
+	pw.logger.Printf("🔁 Recurrence: pod %s/%s failed with %s after %s - possible root-cause issue",
+		pod.Namespace, pod.Name, errorType, since)
+
+	if pw.refix {
+		pw.logger.Printf("🔧 Fixing pod %s/%s again (--refix)", pod.Namespace, pod.Name)
+		return false
+	}
+
+	pw.reportHeuristicDiagnosis(pod, events, errorType)
+	pw.logger.Printf("🚨 Not fixing pod %s/%s again - investigate the root cause or use --refix", pod.Namespace, pod.Name)
+
+	record := pw.newFixRecord(pod, errorType, nil)
+	record.RequiresHumanIntervention = true
+	record.Message = "failed again after " + since + "; not re-fixed"
+	pw.recordFix(record)
+	return true
+}
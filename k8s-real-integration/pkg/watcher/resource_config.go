@@ -0,0 +1,119 @@
+package watcher
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// resourceIssue is an invalid resource setting on a container, with the value that fixes it
+type resourceIssue struct {
+	Container string
+	Problem   string
+	Fix       string
+}
+
+// isMissingReference reports whether a config error message names a missing ConfigMap or Secret
+func isMissingReference(message string) bool {
+	message = strings.ToLower(message)
+	return (strings.Contains(message, "configmap") || strings.Contains(message, "secret")) &&
+		strings.Contains(message, "not found")
+}
+
+// configErrorMessage returns the waiting message of the first container stuck on a config error
+func configErrorMessage(pod *v1.Pod) string {
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if waiting := containerStatus.State.Waiting; waiting != nil && waiting.Message != "" {
+			return waiting.Message
+		}
+	}
+	return ""
+}
+
+// requestLimitIssues finds resource requests that exceed the container's own limits
+func requestLimitIssues(container v1.Container) []resourceIssue {
+	var issues []resourceIssue
+	for name, request := range container.Resources.Requests {
+		limit, ok := container.Resources.Limits[name]
+		if !ok || request.Cmp(limit) <= 0 {
+			continue
+		}
+		issues = append(issues, resourceIssue{
+			Container: container.Name,
+			Problem:   fmt.Sprintf("%s request %s exceeds limit %s", name, request.String(), limit.String()),
+			Fix:       fmt.Sprintf("set %s request to %s", name, limit.String()),
+		})
+	}
+	return issues
+}
+
+// limitRangeIssues finds container resources outside the min/max of a LimitRange
+func limitRangeIssues(container v1.Container, limitRange v1.LimitRange) []resourceIssue {
+	var issues []resourceIssue
+	for _, item := range limitRange.Spec.Limits {
+		if item.Type != v1.LimitTypeContainer {
+			continue
+		}
+		for name, max := range item.Max {
+			if value, ok := container.Resources.Limits[name]; ok && value.Cmp(max) > 0 {
+				issues = append(issues, resourceIssue{
+					Container: container.Name,
+					Problem: fmt.Sprintf("%s limit %s violates LimitRange %s max %s",
+						name, value.String(), limitRange.Name, max.String()),
+					Fix: fmt.Sprintf("set %s limit to at most %s", name, max.String()),
+				})
+			}
+			if value, ok := container.Resources.Requests[name]; ok && value.Cmp(max) > 0 {
+				issues = append(issues, resourceIssue{
+					Container: container.Name,
+					Problem: fmt.Sprintf("%s request %s violates LimitRange %s max %s",
+						name, value.String(), limitRange.Name, max.String()),
+					Fix: fmt.Sprintf("set %s request to at most %s", name, max.String()),
+				})
+			}
+		}
+		for name, min := range item.Min {
+			if value, ok := container.Resources.Requests[name]; ok && value.Cmp(min) < 0 {
+				issues = append(issues, resourceIssue{
+					Container: container.Name,
+					Problem: fmt.Sprintf("%s request %s violates LimitRange %s min %s",
+						name, value.String(), limitRange.Name, min.String()),
+					Fix: fmt.Sprintf("set %s request to at least %s", name, min.String()),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// diagnoseResourceConfig tells a config error caused by invalid resource settings apart from
+// a missing ConfigMap/Secret, and logs the exact invalid relationship with its fix
+func (pw *PodWatcher) diagnoseResourceConfig(pod *v1.Pod) {
+	if message := configErrorMessage(pod); isMissingReference(message) {
+		pw.logger.Printf("🔗 Config error on pod %s/%s is a missing reference: %s", pod.Namespace, pod.Name, message)
+		return
+	}
+
+	limitRanges, err := pw.k8sClient.ListLimitRanges(pw.ctx, pod.Namespace)
+	if err != nil {
+		pw.logger.Printf("⚠️  Could not check LimitRanges for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+
+	var issues []resourceIssue
+	for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		issues = append(issues, requestLimitIssues(container)...)
+		for _, limitRange := range limitRanges {
+			issues = append(issues, limitRangeIssues(container, limitRange)...)
+		}
+	}
+	if len(issues) == 0 {
+		return
+	}
+
+	pw.logger.Printf("📏 Invalid resource settings on pod %s/%s:", pod.Namespace, pod.Name)
+	for _, issue := range issues {
+		pw.logger.Printf("   ❌ container %s: %s", issue.Container, issue.Problem)
+		pw.logger.Printf("   🛠️  Proposed fix: %s", issue.Fix)
+	}
+}
@@ -0,0 +1,122 @@
+package watcher
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// defaultSafeFinalizers are pod finalizers that can be removed from a pod stuck
+// terminating without orphaning anything
+var defaultSafeFinalizers = []string{
+	// Held by the Job controller only to count the pod; removing it just skips the count
+	"batch.kubernetes.io/job-tracking",
+}
+
+// stuckTerminatingTracker detects pods held in Terminating by finalizers
+type stuckTerminatingTracker struct {
+	threshold      time.Duration
+	removeSafe     bool
+	safeFinalizers map[string]bool
+	handled        map[string]bool // pod UID -> already reported
+	mutex          sync.Mutex
+}
+
+// SetStuckTerminating reports pods still terminating threshold after deletion because of
+// finalizers. With removeSafe, finalizers in the safe allowlist (the defaults plus extra)
+// are removed; any other finalizer is only reported. A threshold of 0 disables detection.
+func (pw *PodWatcher) SetStuckTerminating(threshold time.Duration, removeSafe bool, extraSafeFinalizers []string) {
+	safe := make(map[string]bool)
+	for _, finalizer := range append(defaultSafeFinalizers, extraSafeFinalizers...) {
+		if finalizer = strings.TrimSpace(finalizer); finalizer != "" {
+			safe[finalizer] = true
+		}
+	}
+
+	pw.stuckTerminating = &stuckTerminatingTracker{
+		threshold:      threshold,
+		removeSafe:     removeSafe,
+		safeFinalizers: safe,
+		handled:        make(map[string]bool),
+	}
+}
+
+// isStuckTerminating reports whether a pod has been terminating longer than the threshold
+// while still holding finalizers, and has not been handled yet
+func (pw *PodWatcher) isStuckTerminating(pod *v1.Pod) bool {
+	t := pw.stuckTerminating
+	if t == nil || t.threshold <= 0 || pod.DeletionTimestamp == nil || len(pod.Finalizers) == 0 {
+		return false
+	}
+	if time.Since(pod.DeletionTimestamp.Time) < t.threshold {
+		return false
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return !t.handled[string(pod.UID)]
+}
+
+// handleStuckTerminating reports a stuck pod and, if enabled, removes its safe finalizers
+func (pw *PodWatcher) handleStuckTerminating(pod *v1.Pod) {
+	t := pw.stuckTerminating
+	t.mutex.Lock()
+	t.handled[string(pod.UID)] = true
+	t.mutex.Unlock()
+
+	stuckFor := time.Since(pod.DeletionTimestamp.Time).Round(time.Second)
+	pw.logger.Printf("⏳ Pod %s/%s stuck terminating for %v, finalizers: %s",
+		pod.Namespace, pod.Name, stuckFor, strings.Join(pod.Finalizers, ", "))
+
+	var safe, remaining []string
+	for _, finalizer := range pod.Finalizers {
+		if t.safeFinalizers[finalizer] {
+			safe = append(safe, finalizer)
+		} else {
+			remaining = append(remaining, finalizer)
+		}
+	}
+
+	record := pw.newFixRecord(pod, "StuckTerminating", nil)
+	record.Strategy = "remove_finalizers"
+
+	switch {
+	case len(safe) == 0:
+		pw.logger.Printf("🚨 No known-safe finalizers on pod %s/%s - human intervention required", pod.Namespace, pod.Name)
+		record.RequiresHumanIntervention = true
+		record.Message = "no known-safe finalizers: " + strings.Join(pod.Finalizers, ", ")
+	case pw.paused.Load():
+		pw.logger.Printf("⏸️  Not removing finalizers from pod %s/%s while paused", pod.Namespace, pod.Name)
+		t.mutex.Lock()
+		delete(t.handled, string(pod.UID))
+		t.mutex.Unlock()
+		return
+	case !t.removeSafe:
+		pw.logger.Printf("💡 Safe to remove from pod %s/%s: %s (use --force-delete-stuck)",
+			pod.Namespace, pod.Name, strings.Join(safe, ", "))
+		return
+	default:
+		err := pw.k8sClient.SetPodFinalizers(pw.ctx, pod.Namespace, pod.Name, pod.ResourceVersion, remaining)
+		if err != nil {
+			pw.logger.Printf("❌ Failed to remove finalizers from pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			record.Message = err.Error()
+			break
+		}
+		for _, finalizer := range safe {
+			pw.logger.Printf("🧹 Removed finalizer %s from pod %s/%s: terminating for %v and finalizer is on the safe list",
+				finalizer, pod.Namespace, pod.Name, stuckFor)
+		}
+		if len(remaining) > 0 {
+			pw.logger.Printf("🚨 Pod %s/%s still held by unsafe finalizers: %s",
+				pod.Namespace, pod.Name, strings.Join(remaining, ", "))
+			record.RequiresHumanIntervention = true
+		}
+		record.Success = true
+		record.Message = fmt.Sprintf("removed finalizers: %s", strings.Join(safe, ", "))
+	}
+
+	pw.recordFix(record)
+}
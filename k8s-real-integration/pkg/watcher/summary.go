@@ -0,0 +1,270 @@
+package watcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// Session summary formats accepted by --summary-format
+const (
+	SummaryFormatTable    = "table"
+	SummaryFormatJSON     = "json"
+	SummaryFormatMarkdown = "markdown"
+)
+
+// ValidateSummaryFormat checks that format is a known session summary format
+func ValidateSummaryFormat(format string) error {
+	switch format {
+	case SummaryFormatTable, SummaryFormatJSON, SummaryFormatMarkdown:
+		return nil
+	default:
+		return fmt.Errorf("invalid summary format %q (must be %s, %s or %s)",
+			format, SummaryFormatTable, SummaryFormatJSON, SummaryFormatMarkdown)
+	}
+}
+
+// SessionCounts counts the fix records of a whole session. Unlike the recent fixes ring,
+// which keeps only the latest records, the counts are never truncated.
+type SessionCounts struct {
+	ErrorCounts map[string]int
+	Fixed       int
+	FailedFixes int
+	Recurrences int
+}
+
+// Add returns the sum of two session counts, e.g. of two watchers
+func (c SessionCounts) Add(other SessionCounts) SessionCounts {
+	sum := SessionCounts{
+		ErrorCounts: make(map[string]int),
+		Fixed:       c.Fixed + other.Fixed,
+		FailedFixes: c.FailedFixes + other.FailedFixes,
+		Recurrences: c.Recurrences + other.Recurrences,
+	}
+	for errorType, count := range c.ErrorCounts {
+		sum.ErrorCounts[errorType] += count
+	}
+	for errorType, count := range other.ErrorCounts {
+		sum.ErrorCounts[errorType] += count
+	}
+	return sum
+}
+
+// sessionCounter accumulates SessionCounts as fixes are recorded
+type sessionCounter struct {
+	counts SessionCounts
+	mutex  sync.Mutex
+}
+
+// record counts one fix record
+func (c *sessionCounter) record(fix FixRecord) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.counts.ErrorCounts == nil {
+		c.counts.ErrorCounts = make(map[string]int)
+	}
+	c.counts.ErrorCounts[fix.ErrorType]++
+	if fix.Recurrence {
+		c.counts.Recurrences++
+	}
+	if fix.Success {
+		c.counts.Fixed++
+	} else {
+		c.counts.FailedFixes++
+	}
+}
+
+// snapshot returns a copy of the counts so far
+func (c *sessionCounter) snapshot() SessionCounts {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return SessionCounts{}.Add(c.counts)
+}
+
+// GetSessionCounts returns the counts of every fix recorded since the watcher was created
+func (pw *PodWatcher) GetSessionCounts() SessionCounts {
+	return pw.sessionCounts.snapshot()
+}
+
+// SessionSummary describes what the watchers did during one monitoring session. The counts
+// cover the whole session; the FixedPods and Recurrences lists only the most recent records.
+type SessionSummary struct {
+	ProcessedPods   []string       `json:"processed_pods"`
+	ErrorCounts     map[string]int `json:"error_counts"`
+	FixedCount      int            `json:"fixed_count"`
+	FixedPods       []FixRecord    `json:"fixed_pods"`
+	FailedFixes     int            `json:"failed_fixes"`
+	RecurrenceCount int            `json:"recurrence_count"`
+	Recurrences     []FixRecord    `json:"recurrences"`
+}
+
+// NewSessionSummary builds a session summary from processed pod keys, the session's fix
+// counts and its most recent fix records
+func NewSessionSummary(processedPods []string, counts SessionCounts, fixes []FixRecord) SessionSummary {
+	summary := SessionSummary{
+		ProcessedPods:   processedPods,
+		ErrorCounts:     SessionCounts{}.Add(counts).ErrorCounts,
+		FixedCount:      counts.Fixed,
+		FixedPods:       []FixRecord{},
+		FailedFixes:     counts.FailedFixes,
+		RecurrenceCount: counts.Recurrences,
+		Recurrences:     []FixRecord{},
+	}
+	if summary.ProcessedPods == nil {
+		summary.ProcessedPods = []string{}
+	}
+
+	for _, fix := range fixes {
+		if fix.Recurrence {
+			summary.Recurrences = append(summary.Recurrences, fix)
+		}
+		if fix.Success {
+			summary.FixedPods = append(summary.FixedPods, fix)
+		}
+	}
+	return summary
+}
+
+// Render formats the summary as a human table, JSON or Markdown
+func (s SessionSummary) Render(format string) (string, error) {
+	switch format {
+	case SummaryFormatJSON:
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal session summary: %w", err)
+		}
+		return string(data) + "\n", nil
+	case SummaryFormatMarkdown:
+		return s.renderMarkdown(), nil
+	case SummaryFormatTable, "":
+		return s.renderTable(), nil
+	default:
+		return "", ValidateSummaryFormat(format)
+	}
+}
+
+// errorTypes returns the error types seen in the session, most frequent first
+func (s SessionSummary) errorTypes() []string {
+	types := make([]string, 0, len(s.ErrorCounts))
+	for errorType := range s.ErrorCounts {
+		types = append(types, errorType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if s.ErrorCounts[types[i]] != s.ErrorCounts[types[j]] {
+			return s.ErrorCounts[types[i]] > s.ErrorCounts[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// latestNote marks a list that holds only the latest of total records
+func (s SessionSummary) latestNote(listed, total int) string {
+	if listed >= total {
+		return ""
+	}
+	return fmt.Sprintf(" (latest %d listed)", listed)
+}
+
+// podLabel returns the pod key of a fix record, prefixed by its cluster when set
+func podLabel(fix FixRecord) string {
+	label := fmt.Sprintf("%s/%s", fix.Namespace, fix.PodName)
+	if fix.Cluster != "" {
+		label = fix.Cluster + ":" + label
+	}
+	return label
+}
+
+// renderTable formats the summary for a terminal
+func (s SessionSummary) renderTable() string {
+	var buf bytes.Buffer
+
+	if len(s.ProcessedPods) == 0 {
+		buf.WriteString("📊 No failed pods were detected during monitoring\n")
+		return buf.String()
+	}
+
+	fmt.Fprintf(&buf, "📊 Processed %d failed pods:\n", len(s.ProcessedPods))
+	for _, podKey := range s.ProcessedPods {
+		fmt.Fprintf(&buf, "   - %s\n", podKey)
+	}
+
+	if len(s.ErrorCounts) > 0 {
+		buf.WriteString("\n")
+		tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "   ERROR TYPE\tCOUNT")
+		for _, errorType := range s.errorTypes() {
+			fmt.Fprintf(tw, "   %s\t%d\n", errorType, s.ErrorCounts[errorType])
+		}
+		tw.Flush()
+	}
+
+	if s.FixedCount > 0 {
+		fmt.Fprintf(&buf, "\n✅ Fixed %d pods (%d fix attempts failed)%s:\n", s.FixedCount, s.FailedFixes, s.latestNote(len(s.FixedPods), s.FixedCount))
+		tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "   POD\tERROR TYPE\tSTRATEGY")
+		for _, fix := range s.FixedPods {
+			fmt.Fprintf(tw, "   %s\t%s\t%s\n", podLabel(fix), fix.ErrorType, fix.Strategy)
+		}
+		tw.Flush()
+	}
+
+	if s.RecurrenceCount > 0 {
+		fmt.Fprintf(&buf, "\n🔁 %d pods failed again after an agent fix%s:\n", s.RecurrenceCount, s.latestNote(len(s.Recurrences), s.RecurrenceCount))
+		for _, fix := range s.Recurrences {
+			fmt.Fprintf(&buf, "   - %s (%s)\n", podLabel(fix), fix.ErrorType)
+		}
+	}
+
+	return buf.String()
+}
+
+// renderMarkdown formats the summary for pasting into incident docs and PRs
+func (s SessionSummary) renderMarkdown() string {
+	var buf bytes.Buffer
+
+	buf.WriteString("## Pod monitoring session summary\n\n")
+	fmt.Fprintf(&buf, "- Failed pods processed: %d\n", len(s.ProcessedPods))
+	fmt.Fprintf(&buf, "- Pods fixed: %d\n", s.FixedCount)
+	fmt.Fprintf(&buf, "- Failed fix attempts: %d\n", s.FailedFixes)
+	fmt.Fprintf(&buf, "- Recurrences after an agent fix: %d\n", s.RecurrenceCount)
+
+	if len(s.ErrorCounts) > 0 {
+		buf.WriteString("\n### Errors by type\n\n")
+		buf.WriteString("| Error type | Count |\n")
+		buf.WriteString("|---|---|\n")
+		for _, errorType := range s.errorTypes() {
+			fmt.Fprintf(&buf, "| %s | %d |\n", markdownEscape(errorType), s.ErrorCounts[errorType])
+		}
+	}
+
+	if len(s.FixedPods) > 0 {
+		fmt.Fprintf(&buf, "\n### Fixed pods%s\n\n", s.latestNote(len(s.FixedPods), s.FixedCount))
+		for _, fix := range s.FixedPods {
+			strategy := fix.Strategy
+			if strategy == "" {
+				strategy = "unknown strategy"
+			}
+			fmt.Fprintf(&buf, "- `%s` (%s): %s\n", podLabel(fix), markdownEscape(fix.ErrorType), markdownEscape(strategy))
+		}
+	}
+
+	if len(s.Recurrences) > 0 {
+		fmt.Fprintf(&buf, "\n### Recurrences after an agent fix%s\n\n", s.latestNote(len(s.Recurrences), s.RecurrenceCount))
+		for _, fix := range s.Recurrences {
+			fmt.Fprintf(&buf, "- `%s` (%s)\n", podLabel(fix), markdownEscape(fix.ErrorType))
+		}
+	}
+
+	return buf.String()
+}
+
+// markdownEscape keeps table cells and list items from breaking the Markdown layout
+func markdownEscape(text string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(text)
+}
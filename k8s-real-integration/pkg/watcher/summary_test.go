@@ -0,0 +1,58 @@
+package watcher
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSessionSummaryCountsBeyondRecentFixes(t *testing.T) {
+	pw := NewPodWatcher(nil, nil, "default")
+	defer pw.cancel()
+	pw.SetRecentFixesSize(2)
+
+	for i := 0; i < 5; i++ {
+		pw.recordFix(FixRecord{Namespace: "default", PodName: "web", ErrorType: "CrashLoopBackOff", Success: true})
+	}
+	pw.recordFix(FixRecord{Namespace: "default", PodName: "db", ErrorType: "OOMKilled", Recurrence: true})
+
+	summary := NewSessionSummary([]string{"default/web", "default/db"}, pw.GetSessionCounts(), pw.GetRecentFixes())
+	if summary.FixedCount != 5 || summary.FailedFixes != 1 || summary.RecurrenceCount != 1 {
+		t.Errorf("counts = %d fixed, %d failed, %d recurrences; want 5, 1, 1",
+			summary.FixedCount, summary.FailedFixes, summary.RecurrenceCount)
+	}
+	if summary.ErrorCounts["CrashLoopBackOff"] != 5 || summary.ErrorCounts["OOMKilled"] != 1 {
+		t.Errorf("error counts = %v", summary.ErrorCounts)
+	}
+	if len(summary.FixedPods) != 1 {
+		t.Errorf("listed %d fixed pods, want the 1 still in the recent fixes ring", len(summary.FixedPods))
+	}
+
+	table, err := summary.Render(SummaryFormatTable)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(table, "Fixed 5 pods (1 fix attempts failed) (latest 1 listed)") {
+		t.Errorf("table does not report the session counts:\n%s", table)
+	}
+}
+
+func TestInformationalRecordsAreNotCounted(t *testing.T) {
+	pw := NewPodWatcher(nil, nil, "default")
+	defer pw.cancel()
+	metrics := NewMetrics()
+	pw.SetMetrics(metrics)
+
+	pw.recordFix(FixRecord{Namespace: "default", PodName: "web", ErrorType: "CreateContainerConfigError",
+		Informational: true, Message: `ConfigMap "app-config" now exists`})
+
+	if len(pw.GetRecentFixes()) != 1 {
+		t.Error("an informational record should still be listed with the recent fixes")
+	}
+	counts := pw.GetSessionCounts()
+	if counts.Fixed != 0 || counts.FailedFixes != 0 || len(counts.ErrorCounts) != 0 {
+		t.Errorf("informational record was counted in the session: %+v", counts)
+	}
+	if page := scrape(t, metrics); strings.Contains(page, "CreateContainerConfigError") {
+		t.Errorf("informational record was counted in the metrics:\n%s", page)
+	}
+}
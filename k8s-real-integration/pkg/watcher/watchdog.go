@@ -0,0 +1,78 @@
+package watcher
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// defaultStallTimeout is how long the watch loop may go without completing a scan
+// before the watchdog restarts it
+const defaultStallTimeout = 5 * time.Minute
+
+// errWatchSuperseded is returned by a watch loop that the watchdog has replaced
+var errWatchSuperseded = errors.New("watch loop superseded by watchdog restart")
+
+// SetStallTimeout sets how long the watch loop may go without activity before it is
+// considered wedged and restarted. A timeout of 0 disables the watchdog.
+func (pw *PodWatcher) SetStallTimeout(timeout time.Duration) {
+	pw.stallTimeout = timeout
+}
+
+// markActivity records that the watch loop received events or completed a reconcile
+func (pw *PodWatcher) markActivity() {
+	pw.lastActivity.Store(time.Now().UnixNano())
+	if pw.stalled.Swap(false) {
+		pw.logger.Printf("✅ Watch loop recovered, watcher is ready again")
+	}
+}
+
+// idleFor returns how long the watch loop has gone without activity. A loop that is
+// busy processing a pod is not idle.
+func (pw *PodWatcher) idleFor() time.Duration {
+	if pw.processing.Load() > 0 {
+		return 0
+	}
+	return time.Since(time.Unix(0, pw.lastActivity.Load()))
+}
+
+// Ready reports an error when the watch loop is considered wedged
+func (pw *PodWatcher) Ready() error {
+	if pw.stalled.Load() {
+		idle := time.Since(time.Unix(0, pw.lastActivity.Load())).Round(time.Second)
+		return fmt.Errorf("pod watcher for namespace %s stalled: no activity for %v", pw.namespace, idle)
+	}
+	return nil
+}
+
+// watchdog restarts the watch loop when it stops making progress
+func (pw *PodWatcher) watchdog() {
+	checkInterval := pw.stallTimeout / 4
+	if checkInterval < time.Second {
+		checkInterval = time.Second
+	}
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-pw.stopCh:
+			return
+		case <-ticker.C:
+			idle := pw.idleFor()
+			if idle < pw.stallTimeout {
+				continue
+			}
+
+			pw.stalled.Store(true)
+			pw.logger.Printf("🐕 Watchdog: no watch activity for %v (limit %v), restarting watch loop",
+				idle.Round(time.Second), pw.stallTimeout)
+
+			// The wedged loop cannot be interrupted; bump the generation so it exits
+			// as soon as it unblocks, and start a fresh loop alongside it
+			generation := pw.watchGeneration.Add(1)
+			pw.lastActivity.Store(time.Now().UnixNano())
+			go pw.watchLoop(generation)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package watcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s-real-integration-go/pkg/k8s"
+	"k8s-real-integration-go/pkg/reflexion"
+)
+
+func TestIdleForIgnoresTimeSpentProcessing(t *testing.T) {
+	pw := NewPodWatcher(nil, nil, "default")
+	defer pw.cancel()
+	pw.lastActivity.Store(time.Now().Add(-time.Hour).UnixNano())
+
+	if idle := pw.idleFor(); idle < time.Hour {
+		t.Fatalf("idleFor = %v, want at least an hour", idle)
+	}
+	pw.processing.Add(1)
+	if idle := pw.idleFor(); idle != 0 {
+		t.Errorf("idleFor = %v while processing a pod, want 0", idle)
+	}
+}
+
+func TestWatchedPodIsNotIdleDuringSlowReflexion(t *testing.T) {
+	pod := crashingPod("web-1", "uid-00000001")
+
+	var pw *PodWatcher
+	var idleDuringCall time.Duration = -1
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/reflexion/process-with-k8s-data" {
+			idleDuringCall = pw.idleFor()
+		}
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client := k8s.NewClientFromClientset(fake.NewSimpleClientset(pod))
+	pw = NewPodWatcher(client, reflexion.NewClientWithOptions(ts.URL, reflexion.WithMaxAttempts(1)), "default")
+	defer pw.cancel()
+
+	// Pretend the stall timeout has long passed when the event arrives
+	pw.lastActivity.Store(time.Now().Add(-time.Hour).UnixNano())
+	if err := pw.handleWatchEvent(watch.Event{Type: watch.Modified, Object: pod}); err != nil {
+		t.Fatalf("handleWatchEvent: %v", err)
+	}
+
+	if idleDuringCall != 0 {
+		t.Errorf("idleFor during the reflexion call = %v, want 0", idleDuringCall)
+	}
+	if pw.processing.Load() != 0 {
+		t.Errorf("processing count = %d after the event, want 0", pw.processing.Load())
+	}
+}
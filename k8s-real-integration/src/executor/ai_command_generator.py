@@ -12,6 +12,11 @@ from langchain_core.messages import SystemMessage, HumanMessage
 logger = structlog.get_logger()
 logger.info("AI Command Generator module loaded - Enhanced logging enabled")
 
+# Bounds on the log excerpt included in the prompt
+MAX_PROMPT_LOG_LINES = 20
+MAX_PROMPT_LOG_CHARS = 4000
+LOG_ERROR_KEYWORDS = ("error", "failed", "exit", "exception", "panic", "fatal", "killed", "traceback")
+
 
 class AICommandGenerator:
     """AI-powered kubectl command generator using GPT-4"""
@@ -120,10 +125,7 @@ class AICommandGenerator:
                 error_messages.append(event.get("message", ""))
         
         # Extract log errors
-        log_errors = []
-        for log in logs[-10:]:  # Last 10 log lines
-            if any(keyword in log.lower() for keyword in ["error", "failed", "exit"]):
-                log_errors.append(log)
+        log_errors = self._relevant_log_lines(logs)
         
         return {
             "error_type": error_type,
@@ -289,6 +291,20 @@ LOG ERRORS:
             # Try to extract JSON from response
             return self._extract_json_from_response(response.content)
     
+    def _relevant_log_lines(self, logs: List[str]) -> List[str]:
+        """Pick the most recent error-related log lines, bounded in count and size"""
+        relevant = [log for log in logs if any(keyword in log.lower() for keyword in LOG_ERROR_KEYWORDS)]
+
+        selected = []
+        total_chars = 0
+        for log in reversed(relevant):
+            if len(selected) >= MAX_PROMPT_LOG_LINES or total_chars + len(log) > MAX_PROMPT_LOG_CHARS:
+                break
+            selected.append(log)
+            total_chars += len(log)
+
+        return list(reversed(selected))
+    
     def _extract_json_from_response(self, response_text: str) -> Dict[str, List[str]]:
         """Extract JSON from GPT-4 response text"""
         
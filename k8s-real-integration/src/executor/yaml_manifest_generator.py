@@ -228,6 +228,8 @@ CURRENT POD CONFIGURATION:
 ERROR DETAILS:
 - Error Messages: {json.dumps(real_k8s_data.get('events', []), indent=2)}
 - Container Status: {json.dumps(real_k8s_data.get('container_statuses', []), indent=2)}
+- Container Diagnostics (exit code meaning, OOMKilled, restarts, back-off): {json.dumps(real_k8s_data.get('container_diagnostics') or [], indent=2)}
+- Recent Changes (likely root cause if present): {json.dumps(real_k8s_data.get('recent_changes') or [], indent=2)}
 
 REFLEXION LESSONS LEARNED:
 {lessons_text}